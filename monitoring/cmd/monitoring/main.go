@@ -7,6 +7,8 @@ import (
 
 	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/erc20"
 	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/proxy"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/uptimefeed"
 	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
 
 	"github.com/smartcontractkit/chainlink-starknet/monitoring/pkg/monitoring"
@@ -40,16 +42,28 @@ func main() {
 	if err != nil {
 		log.Fatalw("failed to build a starknet.Client", "error", err)
 	}
+	var starknetReader starknet.Reader = starknetClient
+	if cacheSize := starknetConfig.GetRPCCacheSize(); cacheSize > 0 {
+		starknetReader = starknet.NewCachedClient(starknetClient, cacheSize)
+	}
 	ocr2Client, err := ocr2.NewClient(
-		starknetClient,
+		starknetReader,
 		logger.With(log, "component", "ocr2-client"),
 	)
 	if err != nil {
 		log.Fatalw("failed to build a ocr2.Client", "error", err)
 	}
 
+	proxyClient, err := proxy.NewClient(
+		starknetReader,
+		logger.With(log, "component", "proxy-client"),
+	)
+	if err != nil {
+		log.Fatalw("failed to build a proxy.Client", "error", err)
+	}
+
 	strTokenClient, err := erc20.NewClient(
-		starknetClient,
+		starknetReader,
 		logger.With(log, "component", "erc20-client"),
 		starknetConfig.GetStrkTokenAddress(),
 	)
@@ -76,15 +90,17 @@ func main() {
 	}
 
 	// per-feed factories
-	proxySourceFactory := monitoring.NewProxySourceFactory(ocr2Client)
+	proxySourceFactory := monitoring.NewProxySourceFactory(proxyClient)
 	transmissionsDetailsSourceFactory := monitoring.NewTransmissionDetailsSourceFactory(ocr2Client)
-	monitor.SourceFactories = append(monitor.SourceFactories, proxySourceFactory, transmissionsDetailsSourceFactory)
+	transmitterProfitabilitySourceFactory := monitoring.NewTransmitterProfitabilitySourceFactory(ocr2Client)
+	monitor.SourceFactories = append(monitor.SourceFactories, proxySourceFactory, transmissionsDetailsSourceFactory, transmitterProfitabilitySourceFactory)
 
 	metricsBuilder := monitoring.NewMetrics(logger.With(log, "component", "starknet-metrics-builder"))
 
 	prometheusExporterFactory := monitoring.NewPrometheusExporterFactory(metricsBuilder)
 	transmissionsDetailsExporterFactory := monitoring.NewTransmissionDetailsExporterFactory(metricsBuilder)
-	monitor.ExporterFactories = append(monitor.ExporterFactories, prometheusExporterFactory, transmissionsDetailsExporterFactory)
+	transmitterProfitabilityExporterFactory := monitoring.NewTransmitterProfitabilityExporterFactory(metricsBuilder)
+	monitor.ExporterFactories = append(monitor.ExporterFactories, prometheusExporterFactory, transmissionsDetailsExporterFactory, transmitterProfitabilityExporterFactory)
 
 	// network factories
 	nodeBalancesSourceFactory := monitoring.NewNodeBalancesSourceFactory(strTokenClient)
@@ -96,6 +112,18 @@ func main() {
 	)
 	monitor.NetworkExporterFactories = append(monitor.NetworkExporterFactories, nodeBalancesExporterFactory)
 
+	if feedAddress := starknetConfig.GetSequencerUptimeFeedAddress(); feedAddress != nil {
+		uptimeFeedClient, err := uptimefeed.NewClient(
+			starknetReader,
+			logger.With(log, "component", "uptime-feed-client"),
+		)
+		if err != nil {
+			log.Fatalw("failed to build uptime-feed-client", "error", err)
+		}
+		monitor.NetworkSourceFactories = append(monitor.NetworkSourceFactories, monitoring.NewSequencerStatusSourceFactory(uptimeFeedClient, feedAddress))
+		monitor.NetworkExporterFactories = append(monitor.NetworkExporterFactories, monitoring.NewSequencerStatusExporterFactory(metricsBuilder))
+	}
+
 	monitor.Run()
 	log.Info("monitor stopped")
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/NethermindEth/juno/core/felt"
@@ -21,6 +22,10 @@ type StarknetConfig struct {
 	pollInterval     time.Duration
 	linkTokenAddress string
 	strkTokenAddress *felt.Felt
+	// optional, the sequencer status is only monitored if set
+	sequencerUptimeFeedAddress *felt.Felt
+	// optional, responses of immutable RPC requests are cached if set
+	rpcCacheSize int
 }
 
 var _ relayMonitoring.ChainConfig = StarknetConfig{}
@@ -32,8 +37,12 @@ func (s StarknetConfig) GetNetworkID() string            { return s.networkID }
 func (s StarknetConfig) GetChainID() string              { return s.chainID }
 func (s StarknetConfig) GetReadTimeout() time.Duration   { return s.readTimeout }
 func (s StarknetConfig) GetPollInterval() time.Duration  { return s.pollInterval }
+func (s StarknetConfig) GetRPCCacheSize() int            { return s.rpcCacheSize }
 func (s StarknetConfig) GetLinkTokenAddress() string     { return s.linkTokenAddress }
 func (s StarknetConfig) GetStrkTokenAddress() *felt.Felt { return s.strkTokenAddress }
+func (s StarknetConfig) GetSequencerUptimeFeedAddress() *felt.Felt {
+	return s.sequencerUptimeFeedAddress
+}
 
 func (s StarknetConfig) ToMapping() map[string]interface{} {
 	return map[string]interface{}{
@@ -86,6 +95,13 @@ func parseEnvVars(cfg *StarknetConfig) error {
 		}
 		cfg.pollInterval = pollInterval
 	}
+	if value, isPresent := os.LookupEnv("STARKNET_RPC_CACHE_SIZE"); isPresent {
+		cacheSize, err := strconv.Atoi(value)
+		if err != nil || cacheSize < 0 {
+			return fmt.Errorf("failed to parse env var STARKNET_RPC_CACHE_SIZE, must be a positive number of responses: %q", value)
+		}
+		cfg.rpcCacheSize = cacheSize
+	}
 	if value, isPresent := os.LookupEnv("STARKNET_LINK_TOKEN_ADDRESS"); isPresent {
 		cfg.linkTokenAddress = value
 	}
@@ -96,6 +112,13 @@ func parseEnvVars(cfg *StarknetConfig) error {
 		}
 		cfg.strkTokenAddress = feltValue
 	}
+	if value, isPresent := os.LookupEnv("STARKNET_SEQUENCER_UPTIME_FEED_ADDRESS"); isPresent {
+		feltValue, err := starknetutils.HexToFelt(value)
+		if err != nil {
+			return fmt.Errorf("failed to parse env var STARKNET_SEQUENCER_UPTIME_FEED_ADDRESS %w", err)
+		}
+		cfg.sequencerUptimeFeedAddress = feltValue
+	}
 	return nil
 }
 
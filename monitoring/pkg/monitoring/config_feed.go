@@ -21,6 +21,9 @@ type StarknetFeedConfig struct {
 
 	ContractAddress string `json:"contract_address,omitempty"`
 	ProxyAddress    string `json:"proxy_address,omitempty"`
+
+	// set for aggregators storing i128 answers, their transmission events are decoded as signed values
+	Signed bool `json:"signed,omitempty"`
 }
 
 var _ relayMonitoring.FeedConfig = StarknetFeedConfig{}
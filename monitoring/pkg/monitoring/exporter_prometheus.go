@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"sync"
 
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+
 	relayMonitoring "github.com/smartcontractkit/chainlink-common/pkg/monitoring"
 )
 
@@ -79,11 +81,33 @@ func (p *prometheusExporter) Export(ctx context.Context, data interface{}) {
 		p.chainConfig.GetNetworkID(),
 		p.chainConfig.GetNetworkName(),
 	)
+	p.metrics.SetProxyAggregatorMismatch(
+		p.aggregatorMismatch(proxyData),
+		p.feedConfig.ProxyAddress,
+		p.feedConfig.GetID(),
+		p.chainConfig.GetChainID(),
+		p.feedConfig.GetContractStatus(),
+		p.feedConfig.GetContractType(),
+		p.feedConfig.GetName(),
+		p.feedConfig.GetPath(),
+		p.chainConfig.GetNetworkID(),
+		p.chainConfig.GetNetworkName(),
+	)
 	p.addressesMu.Lock()
 	defer p.addressesMu.Unlock()
 	p.addressesSet[p.feedConfig.ProxyAddress] = struct{}{}
 }
 
+// aggregatorMismatch is 1 when the proxy doesn't read from the aggregator in the feeds config, for example after a
+// proposed aggregator was confirmed on-chain without updating the RDD.
+func (p *prometheusExporter) aggregatorMismatch(proxyData ProxyData) float64 {
+	expected, err := starknetutils.HexToFelt(p.feedConfig.ContractAddress)
+	if err != nil || proxyData.Aggregator == nil || !proxyData.Aggregator.Equal(expected) {
+		return 1
+	}
+	return 0
+}
+
 func (p *prometheusExporter) Cleanup(_ context.Context) {
 	p.addressesMu.Lock()
 	defer p.addressesMu.Unlock()
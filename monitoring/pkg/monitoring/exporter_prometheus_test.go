@@ -0,0 +1,58 @@
+package monitoring
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-starknet/monitoring/pkg/monitoring/mocks"
+
+	commonMonitoring "github.com/smartcontractkit/chainlink-common/pkg/monitoring"
+)
+
+func TestPrometheusExporter_AggregatorMismatch(t *testing.T) {
+	chainConfig := generateChainConfig()
+	feedConfig := generateFeedConfig()
+	feedConfig.Multiply = big.NewInt(10)
+
+	expected, err := starknetutils.HexToFelt(feedConfig.ContractAddress)
+	require.NoError(t, err)
+	// generateAddr could return the feed's own address
+	other := new(felt.Felt).Add(expected, new(felt.Felt).SetUint64(1))
+
+	mockMetrics := mocks.NewMetrics(t)
+	factory := NewPrometheusExporterFactory(mockMetrics)
+	exporter, err := factory.NewExporter(commonMonitoring.ExporterParams{
+		ChainConfig: chainConfig,
+		FeedConfig:  feedConfig,
+	})
+	require.NoError(t, err)
+
+	labels := []interface{}{
+		feedConfig.ProxyAddress,
+		feedConfig.GetID(),
+		chainConfig.GetChainID(),
+		feedConfig.GetContractStatus(),
+		feedConfig.GetContractType(),
+		feedConfig.Name,
+		feedConfig.Path,
+		chainConfig.GetNetworkID(),
+		chainConfig.GetNetworkName(),
+	}
+	mockMetrics.On("SetProxyAnswersRaw", append([]interface{}{float64(100)}, labels...)...).Twice()
+	mockMetrics.On("SetProxyAnswers", append([]interface{}{float64(10)}, labels...)...).Twice()
+
+	mockMetrics.On("SetProxyAggregatorMismatch", append([]interface{}{float64(0)}, labels...)...).Once()
+	exporter.Export(context.Background(), ProxyData{Answer: big.NewInt(100), Aggregator: expected})
+
+	mockMetrics.On("SetProxyAggregatorMismatch", append([]interface{}{float64(1)}, labels...)...).Once()
+	exporter.Export(context.Background(), ProxyData{Answer: big.NewInt(100), PhaseID: 2, Aggregator: other})
+
+	mockMetrics.On("CleanupProxy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once()
+	exporter.Cleanup(context.Background())
+}
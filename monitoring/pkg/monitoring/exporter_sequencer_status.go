@@ -0,0 +1,66 @@
+package monitoring
+
+import (
+	"context"
+	"sync"
+
+	commonMonitoring "github.com/smartcontractkit/chainlink-common/pkg/monitoring"
+)
+
+func NewSequencerStatusExporterFactory(metrics Metrics) commonMonitoring.ExporterFactory {
+	return &sequencerStatusExporterFactory{
+		metrics,
+	}
+}
+
+type sequencerStatusExporterFactory struct {
+	metrics Metrics
+}
+
+func (f *sequencerStatusExporterFactory) NewExporter(params commonMonitoring.ExporterParams) (commonMonitoring.Exporter, error) {
+	return &sequencerStatusExporter{
+		metrics:     f.metrics,
+		chainConfig: params.ChainConfig,
+	}, nil
+}
+
+type sequencerStatusExporter struct {
+	metrics     Metrics
+	chainConfig commonMonitoring.ChainConfig
+
+	feedAddressMu sync.Mutex
+	feedAddress   string
+}
+
+func (e *sequencerStatusExporter) Export(ctx context.Context, data interface{}) {
+	envelope, isEnvelope := data.(SequencerStatusEnvelope)
+	if !isEnvelope {
+		return
+	}
+
+	up := 0.0
+	if envelope.Up {
+		up = 1
+	}
+	e.metrics.SetSequencerStatus(
+		up,
+		envelope.SinceChange.Seconds(),
+		envelope.FeedAddress.String(),
+		e.chainConfig.GetNetworkID(),
+		e.chainConfig.GetNetworkName(),
+		e.chainConfig.GetChainID(),
+	)
+
+	e.feedAddressMu.Lock()
+	defer e.feedAddressMu.Unlock()
+	e.feedAddress = envelope.FeedAddress.String()
+}
+
+func (e *sequencerStatusExporter) Cleanup(_ context.Context) {
+	e.feedAddressMu.Lock()
+	defer e.feedAddressMu.Unlock()
+	if e.feedAddress == "" {
+		return
+	}
+	e.metrics.CleanupSequencerStatus(e.feedAddress, e.chainConfig.GetNetworkID(), e.chainConfig.GetNetworkName(), e.chainConfig.GetChainID())
+}
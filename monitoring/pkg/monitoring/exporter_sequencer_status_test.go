@@ -0,0 +1,48 @@
+package monitoring
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-starknet/monitoring/pkg/monitoring/mocks"
+
+	commonMonitoring "github.com/smartcontractkit/chainlink-common/pkg/monitoring"
+)
+
+func TestSequencerStatusExporter(t *testing.T) {
+	chainConfig := generateChainConfig()
+	feedAddress := new(felt.Felt).SetUint64(0x1234)
+
+	mockMetrics := mocks.NewMetrics(t)
+	factory := NewSequencerStatusExporterFactory(mockMetrics)
+	exporter, err := factory.NewExporter(commonMonitoring.ExporterParams{ChainConfig: chainConfig})
+	require.NoError(t, err)
+
+	mockMetrics.On(
+		"SetSequencerStatus",
+		float64(1),
+		float64(90),
+		feedAddress.String(),
+		chainConfig.GetNetworkID(),
+		chainConfig.GetNetworkName(),
+		chainConfig.GetChainID(),
+	).Once()
+	exporter.Export(context.Background(), SequencerStatusEnvelope{
+		FeedAddress: feedAddress,
+		Up:          true,
+		SinceChange: 90 * time.Second,
+	})
+
+	mockMetrics.On(
+		"CleanupSequencerStatus",
+		feedAddress.String(),
+		chainConfig.GetNetworkID(),
+		chainConfig.GetNetworkName(),
+		chainConfig.GetChainID(),
+	).Once()
+	exporter.Cleanup(context.Background())
+}
@@ -0,0 +1,102 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	relayMonitoring "github.com/smartcontractkit/chainlink-common/pkg/monitoring"
+)
+
+// NewTransmitterProfitabilityExporterFactory builds an exporter accumulating the fees and earnings of each
+// transmitter into prometheus counters.
+func NewTransmitterProfitabilityExporterFactory(
+	metrics Metrics,
+) relayMonitoring.ExporterFactory {
+	return &transmitterProfitabilityExporterFactory{
+		metrics,
+	}
+}
+
+type transmitterProfitabilityExporterFactory struct {
+	metrics Metrics
+}
+
+func (p *transmitterProfitabilityExporterFactory) NewExporter(
+	params relayMonitoring.ExporterParams,
+) (relayMonitoring.Exporter, error) {
+	starknetFeedConfig, ok := params.FeedConfig.(StarknetFeedConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected feedConfig to be of type StarknetFeedConfig not %T", params.FeedConfig)
+	}
+	return &transmitterProfitabilityExporter{
+		chainConfig:  params.ChainConfig,
+		feedConfig:   starknetFeedConfig,
+		metrics:      p.metrics,
+		transmitters: map[string]struct{}{},
+	}, nil
+}
+
+type transmitterProfitabilityExporter struct {
+	chainConfig relayMonitoring.ChainConfig
+	feedConfig  StarknetFeedConfig
+	metrics     Metrics
+
+	transmittersMu sync.Mutex
+	transmitters   map[string]struct{}
+}
+
+func (p *transmitterProfitabilityExporter) Export(ctx context.Context, data interface{}) {
+	envelope, found := data.(TransmitterProfitabilityEnvelope)
+	if !found {
+		return
+	}
+
+	p.transmittersMu.Lock()
+	defer p.transmittersMu.Unlock()
+	for _, t := range envelope.Transmitters {
+		p.metrics.AddTransmitterProfitability(
+			float64(t.Transmissions),
+			fromWei(t.FeesFri),
+			fromWei(t.FeesJuels),
+			fromWei(t.EarnedJuels),
+			t.Transmitter,
+			p.feedConfig.ContractAddress,
+			p.feedConfig.GetID(),
+			p.chainConfig.GetChainID(),
+			p.feedConfig.GetContractStatus(),
+			p.feedConfig.GetContractType(),
+			p.feedConfig.Name,
+			p.feedConfig.Path,
+			p.chainConfig.GetNetworkID(),
+			p.chainConfig.GetNetworkName(),
+		)
+		p.transmitters[t.Transmitter] = struct{}{}
+	}
+}
+
+func (p *transmitterProfitabilityExporter) Cleanup(_ context.Context) {
+	p.transmittersMu.Lock()
+	defer p.transmittersMu.Unlock()
+	for transmitter := range p.transmitters {
+		p.metrics.CleanupTransmitterProfitability(
+			transmitter,
+			p.feedConfig.GetContractAddress(),
+			p.feedConfig.GetID(),
+			p.chainConfig.GetChainID(),
+			p.feedConfig.GetContractStatus(),
+			p.feedConfig.GetContractType(),
+			p.feedConfig.GetName(),
+			p.feedConfig.GetPath(),
+			p.chainConfig.GetNetworkID(),
+			p.chainConfig.GetNetworkName(),
+		)
+	}
+}
+
+// fromWei converts an amount in the 18 decimals base unit of a token (FRI, juels) to the token
+func fromWei(amount *big.Int) float64 {
+	val, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), big.NewFloat(1e18)).Float64()
+	return val
+}
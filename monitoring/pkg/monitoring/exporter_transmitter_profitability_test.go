@@ -0,0 +1,74 @@
+package monitoring
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-starknet/monitoring/pkg/monitoring/mocks"
+
+	commonMonitoring "github.com/smartcontractkit/chainlink-common/pkg/monitoring"
+)
+
+func TestTransmitterProfitabilityExporter(t *testing.T) {
+	chainConfig := generateChainConfig()
+	feedConfig := generateFeedConfig()
+
+	mockMetrics := mocks.NewMetrics(t)
+	factory := NewTransmitterProfitabilityExporterFactory(mockMetrics)
+	exporter, err := factory.NewExporter(commonMonitoring.ExporterParams{
+		ChainConfig: chainConfig,
+		FeedConfig:  feedConfig,
+	})
+	require.NoError(t, err)
+
+	oneToken := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	envelope := TransmitterProfitabilityEnvelope{
+		Transmitters: []TransmitterProfitability{{
+			Transmitter:   "0x101",
+			Transmissions: 3,
+			FeesFri:       new(big.Int).Mul(oneToken, big.NewInt(2)),  // 2 STRK
+			FeesJuels:     new(big.Int).Div(oneToken, big.NewInt(10)), // 0.1 LINK
+			EarnedJuels:   new(big.Int).Div(oneToken, big.NewInt(4)),  // 0.25 LINK
+		}},
+	}
+
+	mockMetrics.On(
+		"AddTransmitterProfitability",
+		float64(3),
+		float64(2),
+		0.1,
+		0.25,
+		"0x101",
+		feedConfig.ContractAddress,
+		feedConfig.GetID(),
+		chainConfig.GetChainID(),
+		feedConfig.GetContractStatus(),
+		feedConfig.GetContractType(),
+		feedConfig.Name,
+		feedConfig.Path,
+		chainConfig.GetNetworkID(),
+		chainConfig.GetNetworkName(),
+	).Once()
+	exporter.Export(context.Background(), envelope)
+
+	// other envelopes are ignored
+	exporter.Export(context.Background(), TransmissionsEnvelope{})
+
+	mockMetrics.On(
+		"CleanupTransmitterProfitability",
+		"0x101",
+		feedConfig.ContractAddress,
+		feedConfig.GetID(),
+		chainConfig.GetChainID(),
+		feedConfig.GetContractStatus(),
+		feedConfig.GetContractType(),
+		feedConfig.Name,
+		feedConfig.Path,
+		chainConfig.GetNetworkID(),
+		chainConfig.GetNetworkName(),
+	).Once()
+	exporter.Cleanup(context.Background())
+}
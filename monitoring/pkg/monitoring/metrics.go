@@ -17,8 +17,13 @@ type Metrics interface {
 	CleanupReportObservations(accountAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName string)
 	SetProxyAnswersRaw(answer float64, proxyContractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName string)
 	SetProxyAnswers(answer float64, proxyContractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName string)
+	SetProxyAggregatorMismatch(mismatch float64, proxyContractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName string)
 	CleanupProxy(proxyContractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName string)
+	AddTransmitterProfitability(transmissions, feesSTRK, feesLINK, earnedLINK float64, transmitterAddress, contractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName string)
+	CleanupTransmitterProfitability(transmitterAddress, contractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName string)
 	SetBalance(answer float64, contractAddress, alias, networkId, networkName, chainID string)
+	SetSequencerStatus(up, secondsSinceChange float64, contractAddress, networkID, networkName, chainID string)
+	CleanupSequencerStatus(contractAddress, networkID, networkName, chainID string)
 	CleanupBalance(contractAddress, alias, networkId, networkName, chainID string)
 }
 
@@ -72,6 +77,55 @@ var (
 		},
 		[]string{"proxy_contract_address", "feed_id", "chain_id", "contract_status", "contract_type", "feed_name", "feed_path", "network_id", "network_name"},
 	)
+	proxyAggregatorMismatch = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "proxy_aggregator_mismatch",
+			Help: "Set to 1 when the proxy contract reads from a different aggregator than the one in the feeds config.",
+		},
+		[]string{"proxy_contract_address", "feed_id", "chain_id", "contract_status", "contract_type", "feed_name", "feed_path", "network_id", "network_name"},
+	)
+	sequencerUp = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "starknet_sequencer_up",
+			Help: "Reports 1 if the sequencer uptime feed reports the sequencer as up, 0 if it is down",
+		},
+		[]string{"contract_address", "network_id", "network_name", "chain_id"},
+	)
+	sequencerStatusAge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "starknet_sequencer_status_age_seconds",
+			Help: "Reports the seconds since the sequencer uptime feed status last changed",
+		},
+		[]string{"contract_address", "network_id", "network_name", "chain_id"},
+	)
+	transmitterTransmissions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "starknet_transmitter_transmissions_total",
+			Help: "Counts the transmissions of a transmitter to a feed",
+		},
+		[]string{"transmitter_address", "contract_address", "feed_id", "chain_id", "contract_status", "contract_type", "feed_name", "feed_path", "network_id", "network_name"},
+	)
+	transmitterFeesSTRK = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "starknet_transmitter_fees_strk_total",
+			Help: "Counts the fees (units STRK) a transmitter paid for transmissions to a feed",
+		},
+		[]string{"transmitter_address", "contract_address", "feed_id", "chain_id", "contract_status", "contract_type", "feed_name", "feed_path", "network_id", "network_name"},
+	)
+	transmitterFeesLINK = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "starknet_transmitter_fees_link_total",
+			Help: "Counts the fees a transmitter paid for transmissions to a feed, converted to LINK at the juels per fee coin of each report",
+		},
+		[]string{"transmitter_address", "contract_address", "feed_id", "chain_id", "contract_status", "contract_type", "feed_name", "feed_path", "network_id", "network_name"},
+	)
+	transmitterEarnedLINK = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "starknet_transmitter_earned_link_total",
+			Help: "Counts the reimbursements and transmission payments (units LINK) a feed credited to a transmitter",
+		},
+		[]string{"transmitter_address", "contract_address", "feed_id", "chain_id", "contract_status", "contract_type", "feed_name", "feed_path", "network_id", "network_name"},
+	)
 	contractBalance = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "strk_contract_balance",
@@ -144,6 +198,78 @@ func (d *defaultMetrics) CleanupBalance(contractAddress, alias, networkId, netwo
 	}
 }
 
+func (d *defaultMetrics) AddTransmitterProfitability(transmissions, feesSTRK, feesLINK, earnedLINK float64, transmitterAddress, contractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName string) {
+	labels := prometheus.Labels{
+		"transmitter_address": transmitterAddress,
+		"contract_address":    contractAddress,
+		"feed_id":             feedID,
+		"chain_id":            chainID,
+		"contract_status":     contractStatus,
+		"contract_type":       contractType,
+		"feed_name":           feedName,
+		"feed_path":           feedPath,
+		"network_id":          networkID,
+		"network_name":        networkName,
+	}
+	transmitterTransmissions.With(labels).Add(transmissions)
+	transmitterFeesSTRK.With(labels).Add(feesSTRK)
+	transmitterFeesLINK.With(labels).Add(feesLINK)
+	transmitterEarnedLINK.With(labels).Add(earnedLINK)
+}
+
+func (d *defaultMetrics) CleanupTransmitterProfitability(transmitterAddress, contractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName string) {
+	labels := prometheus.Labels{
+		"transmitter_address": transmitterAddress,
+		"contract_address":    contractAddress,
+		"feed_id":             feedID,
+		"chain_id":            chainID,
+		"contract_status":     contractStatus,
+		"contract_type":       contractType,
+		"feed_name":           feedName,
+		"feed_path":           feedPath,
+		"network_id":          networkID,
+		"network_name":        networkName,
+	}
+	if !transmitterTransmissions.Delete(labels) {
+		d.log.Errorw("failed to delete metric", "name", "starknet_transmitter_transmissions_total", "labels", labels)
+	}
+	if !transmitterFeesSTRK.Delete(labels) {
+		d.log.Errorw("failed to delete metric", "name", "starknet_transmitter_fees_strk_total", "labels", labels)
+	}
+	if !transmitterFeesLINK.Delete(labels) {
+		d.log.Errorw("failed to delete metric", "name", "starknet_transmitter_fees_link_total", "labels", labels)
+	}
+	if !transmitterEarnedLINK.Delete(labels) {
+		d.log.Errorw("failed to delete metric", "name", "starknet_transmitter_earned_link_total", "labels", labels)
+	}
+}
+
+func (d *defaultMetrics) SetSequencerStatus(up, secondsSinceChange float64, contractAddress, networkID, networkName, chainID string) {
+	labels := prometheus.Labels{
+		"contract_address": contractAddress,
+		"network_id":       networkID,
+		"network_name":     networkName,
+		"chain_id":         chainID,
+	}
+	sequencerUp.With(labels).Set(up)
+	sequencerStatusAge.With(labels).Set(secondsSinceChange)
+}
+
+func (d *defaultMetrics) CleanupSequencerStatus(contractAddress, networkID, networkName, chainID string) {
+	labels := prometheus.Labels{
+		"contract_address": contractAddress,
+		"network_id":       networkID,
+		"network_name":     networkName,
+		"chain_id":         chainID,
+	}
+	if !sequencerUp.Delete(labels) {
+		d.log.Errorw("failed to delete metric", "name", "starknet_sequencer_up", "labels", labels)
+	}
+	if !sequencerStatusAge.Delete(labels) {
+		d.log.Errorw("failed to delete metric", "name", "starknet_sequencer_status_age_seconds", "labels", labels)
+	}
+}
+
 func (d *defaultMetrics) SetReportObservations(answer float64, accountAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName string) {
 	reportObservations.With(prometheus.Labels{
 		"account_address": accountAddress,
@@ -203,6 +329,20 @@ func (d *defaultMetrics) SetProxyAnswers(answer float64, proxyContractAddress, f
 	}).Set(answer)
 }
 
+func (d *defaultMetrics) SetProxyAggregatorMismatch(mismatch float64, proxyContractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName string) {
+	proxyAggregatorMismatch.With(prometheus.Labels{
+		"proxy_contract_address": proxyContractAddress,
+		"feed_id":                feedID,
+		"chain_id":               chainID,
+		"contract_status":        contractStatus,
+		"contract_type":          contractType,
+		"feed_name":              feedName,
+		"feed_path":              feedPath,
+		"network_id":             networkID,
+		"network_name":           networkName,
+	}).Set(mismatch)
+}
+
 func (d *defaultMetrics) CleanupProxy(
 	proxyContractAddress, feedID, chainID, contractStatus, contractType string,
 	feedName, feedPath, networkID, networkName string,
@@ -224,4 +364,7 @@ func (d *defaultMetrics) CleanupProxy(
 	if !proxyAnswers.Delete(labels) {
 		d.log.Errorw("failed to delete metric", "name", "proxy_answers", "labels", labels)
 	}
+	if !proxyAggregatorMismatch.Delete(labels) {
+		d.log.Errorw("failed to delete metric", "name", "proxy_aggregator_mismatch", "labels", labels)
+	}
 }
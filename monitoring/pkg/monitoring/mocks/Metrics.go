@@ -9,6 +9,11 @@ type Metrics struct {
 	mock.Mock
 }
 
+// AddTransmitterProfitability provides a mock function with given fields: transmissions, feesSTRK, feesLINK, earnedLINK, transmitterAddress, contractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName
+func (_m *Metrics) AddTransmitterProfitability(transmissions float64, feesSTRK float64, feesLINK float64, earnedLINK float64, transmitterAddress string, contractAddress string, feedID string, chainID string, contractStatus string, contractType string, feedName string, feedPath string, networkID string, networkName string) {
+	_m.Called(transmissions, feesSTRK, feesLINK, earnedLINK, transmitterAddress, contractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName)
+}
+
 // CleanupBalance provides a mock function with given fields: contractAddress, alias, networkId, networkName, chainID
 func (_m *Metrics) CleanupBalance(contractAddress string, alias string, networkId string, networkName string, chainID string) {
 	_m.Called(contractAddress, alias, networkId, networkName, chainID)
@@ -24,16 +29,31 @@ func (_m *Metrics) CleanupReportObservations(accountAddress string, feedID strin
 	_m.Called(accountAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName)
 }
 
+// CleanupSequencerStatus provides a mock function with given fields: contractAddress, networkID, networkName, chainID
+func (_m *Metrics) CleanupSequencerStatus(contractAddress string, networkID string, networkName string, chainID string) {
+	_m.Called(contractAddress, networkID, networkName, chainID)
+}
+
 // CleanupTransmissionGasPrice provides a mock function with given fields: contractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName
 func (_m *Metrics) CleanupTransmissionGasPrice(contractAddress string, feedID string, chainID string, contractStatus string, contractType string, feedName string, feedPath string, networkID string, networkName string) {
 	_m.Called(contractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName)
 }
 
+// CleanupTransmitterProfitability provides a mock function with given fields: transmitterAddress, contractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName
+func (_m *Metrics) CleanupTransmitterProfitability(transmitterAddress string, contractAddress string, feedID string, chainID string, contractStatus string, contractType string, feedName string, feedPath string, networkID string, networkName string) {
+	_m.Called(transmitterAddress, contractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName)
+}
+
 // SetBalance provides a mock function with given fields: answer, contractAddress, alias, networkId, networkName, chainID
 func (_m *Metrics) SetBalance(answer float64, contractAddress string, alias string, networkId string, networkName string, chainID string) {
 	_m.Called(answer, contractAddress, alias, networkId, networkName, chainID)
 }
 
+// SetProxyAggregatorMismatch provides a mock function with given fields: mismatch, proxyContractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName
+func (_m *Metrics) SetProxyAggregatorMismatch(mismatch float64, proxyContractAddress string, feedID string, chainID string, contractStatus string, contractType string, feedName string, feedPath string, networkID string, networkName string) {
+	_m.Called(mismatch, proxyContractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName)
+}
+
 // SetProxyAnswers provides a mock function with given fields: answer, proxyContractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName
 func (_m *Metrics) SetProxyAnswers(answer float64, proxyContractAddress string, feedID string, chainID string, contractStatus string, contractType string, feedName string, feedPath string, networkID string, networkName string) {
 	_m.Called(answer, proxyContractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName)
@@ -49,6 +69,11 @@ func (_m *Metrics) SetReportObservations(answer float64, accountAddress string,
 	_m.Called(answer, accountAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName)
 }
 
+// SetSequencerStatus provides a mock function with given fields: up, secondsSinceChange, contractAddress, networkID, networkName, chainID
+func (_m *Metrics) SetSequencerStatus(up float64, secondsSinceChange float64, contractAddress string, networkID string, networkName string, chainID string) {
+	_m.Called(up, secondsSinceChange, contractAddress, networkID, networkName, chainID)
+}
+
 // SetTransmissionGasPrice provides a mock function with given fields: answer, contractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName
 func (_m *Metrics) SetTransmissionGasPrice(answer float64, contractAddress string, feedID string, chainID string, contractStatus string, contractType string, feedName string, feedPath string, networkID string, networkName string) {
 	_m.Called(answer, contractAddress, feedID, chainID, contractStatus, contractType, feedName, feedPath, networkID, networkName)
@@ -39,6 +39,10 @@ func (s *envelopeSourceFactory) NewSource(
 	if !ok {
 		return nil, fmt.Errorf("expected feedConfig to be of type StarknetFeedConfig not %T", feedConfig)
 	}
+	starknetFeedConfig, ok := feedConfig.(StarknetFeedConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected feedConfig to be of type StarknetFeedConfig not %T", feedConfig)
+	}
 	contractAddress, err := starknetutils.HexToFelt(feedConfig.GetContractAddress())
 	if err != nil {
 		return nil, err
@@ -50,6 +54,7 @@ func (s *envelopeSourceFactory) NewSource(
 	return &envelopeSource{
 		contractAddress,
 		linkTokenAddress,
+		starknetFeedConfig.Signed,
 		s.ocr2Reader,
 	}, nil
 }
@@ -61,6 +66,7 @@ func (s *envelopeSourceFactory) GetType() string {
 type envelopeSource struct {
 	contractAddress  *felt.Felt
 	linkTokenAddress *felt.Felt
+	signed           bool
 	ocr2Reader       ocr2.OCR2Reader
 }
 
@@ -135,7 +141,7 @@ func (s *envelopeSource) fetchLatestNewTransmissionEvent(ctx context.Context, co
 	if err != nil {
 		return latestRound, transmission, fmt.Errorf("failed to fetch latest_round_data: %w", err)
 	}
-	transmissions, err := s.ocr2Reader.NewTransmissionsFromEventsAt(ctx, contractAddress, latestRound.BlockNumber)
+	transmissions, err := s.ocr2Reader.NewTransmissionsFromEventsAt(ctx, contractAddress, latestRound.BlockNumber, s.signed)
 	if err != nil {
 		return latestRound, transmission, fmt.Errorf("failed to fetch new_transmission events: %w", err)
 	}
@@ -68,6 +68,7 @@ func TestEnvelopeSource(t *testing.T) {
 		mock.Anything, // ctx
 		feedContractAddressFelt,
 		ocr2ClientLatestRoundDataResponse.BlockNumber,
+		false, // signed
 	).Return(ocr2ClientNewTransmissionEventAtResponse, nil).Once()
 	ocr2Reader.On(
 		"LatestConfigDetails",
@@ -10,23 +10,26 @@ import (
 
 	relayMonitoring "github.com/smartcontractkit/chainlink-common/pkg/monitoring"
 
-	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/proxy"
 )
 
 type ProxyData struct {
-	Answer *big.Int
+	Answer  *big.Int
+	PhaseID uint64
+	// Aggregator is the aggregator the proxy currently reads from
+	Aggregator *felt.Felt
 }
 
 func NewProxySourceFactory(
-	ocr2Reader ocr2.OCR2Reader,
+	proxyReader proxy.ProxyReader,
 ) relayMonitoring.SourceFactory {
 	return &proxySourceFactory{
-		ocr2Reader,
+		proxyReader,
 	}
 }
 
 type proxySourceFactory struct {
-	ocr2Reader ocr2.OCR2Reader
+	proxyReader proxy.ProxyReader
 }
 
 func (s *proxySourceFactory) NewSource(
@@ -43,7 +46,7 @@ func (s *proxySourceFactory) NewSource(
 	}
 	return &proxySource{
 		contractAddress,
-		s.ocr2Reader,
+		s.proxyReader,
 	}, nil
 }
 
@@ -53,15 +56,21 @@ func (s *proxySourceFactory) GetType() string {
 
 type proxySource struct {
 	contractAddress *felt.Felt
-	ocr2Reader      ocr2.OCR2Reader
+	proxyReader     proxy.ProxyReader
 }
 
 func (s *proxySource) Fetch(ctx context.Context) (interface{}, error) {
-	latestRoundData, err := s.ocr2Reader.LatestRoundData(ctx, s.contractAddress)
+	latestRoundData, err := s.proxyReader.LatestRoundData(ctx, s.contractAddress)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't fetch latest_round_data: %w", err)
 	}
+	aggregator, err := s.proxyReader.Aggregator(ctx, s.contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch aggregator: %w", err)
+	}
 	return ProxyData{
-		Answer: latestRoundData.Answer,
+		Answer:     latestRoundData.Answer,
+		PhaseID:    latestRoundData.PhaseID,
+		Aggregator: aggregator,
 	}, nil
 }
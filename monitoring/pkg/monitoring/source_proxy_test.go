@@ -11,11 +11,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2"
-	ocr2Mocks "github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2/mocks"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/proxy"
+	proxyMocks "github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/proxy/mocks"
 )
 
 func TestProxySource(t *testing.T) {
-	// This test makes sure that the mapping between the response from the ocr2.Client
+	// This test makes sure that the mapping between the response from the proxy.Client
 	// method calls and the output of the Proxy source is correct.
 
 	chainConfig := generateChainConfig()
@@ -24,14 +25,22 @@ func TestProxySource(t *testing.T) {
 	proxyContractAddressFelt, err := starknetutils.HexToFelt(feedConfig.ProxyAddress)
 	require.NoError(t, err)
 
-	ocr2Reader := ocr2Mocks.NewOCR2Reader(t)
-	ocr2Reader.On(
+	aggregatorAddressFelt, err := starknetutils.HexToFelt(feedConfig.ContractAddress)
+	require.NoError(t, err)
+
+	proxyReader := proxyMocks.NewProxyReader(t)
+	proxyReader.On(
 		"LatestRoundData",
 		mock.Anything, // ctx
 		proxyContractAddressFelt,
-	).Return(ocr2ClientLatestRoundDataResponseForProxy, nil).Once()
+	).Return(proxy.RoundData{RoundData: ocr2ClientLatestRoundDataResponseForProxy, PhaseID: 2}, nil).Once()
+	proxyReader.On(
+		"Aggregator",
+		mock.Anything, // ctx
+		proxyContractAddressFelt,
+	).Return(aggregatorAddressFelt, nil).Once()
 
-	factory := NewProxySourceFactory(ocr2Reader)
+	factory := NewProxySourceFactory(proxyReader)
 	source, err := factory.NewSource(chainConfig, feedConfig)
 	require.NoError(t, err)
 	rawProxyData, err := source.Fetch(context.Background())
@@ -43,6 +52,8 @@ func TestProxySource(t *testing.T) {
 		ocr2ClientLatestRoundDataResponseForProxy.Answer.String(),
 		proxyData.Answer.String(),
 	)
+	require.Equal(t, uint64(2), proxyData.PhaseID)
+	require.Equal(t, aggregatorAddressFelt, proxyData.Aggregator)
 }
 
 var (
@@ -0,0 +1,62 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+	commonMonitoring "github.com/smartcontractkit/chainlink-common/pkg/monitoring"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/uptimefeed"
+)
+
+type SequencerStatusEnvelope struct {
+	FeedAddress *felt.Felt
+	Up          bool
+	RoundID     uint32
+	// SinceChange is the time since the status last flipped, as of the fetch
+	SinceChange time.Duration
+}
+
+type sequencerStatusSourceFactory struct {
+	uptimeFeedReader uptimefeed.UptimeFeedReader
+	feedAddress      *felt.Felt
+}
+
+// NewSequencerStatusSourceFactory builds a network source reading the sequencer uptime feed at feedAddress
+func NewSequencerStatusSourceFactory(uptimeFeedReader uptimefeed.UptimeFeedReader, feedAddress *felt.Felt) *sequencerStatusSourceFactory {
+	return &sequencerStatusSourceFactory{
+		uptimeFeedReader: uptimeFeedReader,
+		feedAddress:      feedAddress,
+	}
+}
+
+func (f *sequencerStatusSourceFactory) NewSource(
+	_ commonMonitoring.ChainConfig,
+	_ []commonMonitoring.NodeConfig,
+) (commonMonitoring.Source, error) {
+	return &sequencerStatusSource{uptimeFeedReader: f.uptimeFeedReader, feedAddress: f.feedAddress}, nil
+}
+
+func (f *sequencerStatusSourceFactory) GetType() string {
+	return "sequencerStatus"
+}
+
+type sequencerStatusSource struct {
+	uptimeFeedReader uptimefeed.UptimeFeedReader
+	feedAddress      *felt.Felt
+}
+
+func (s *sequencerStatusSource) Fetch(ctx context.Context) (interface{}, error) {
+	round, err := s.uptimeFeedReader.LatestRoundData(ctx, s.feedAddress)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch the latest round of the uptime feed: %w", err)
+	}
+	return SequencerStatusEnvelope{
+		FeedAddress: s.feedAddress,
+		Up:          round.SequencerUp(),
+		RoundID:     round.RoundID,
+		SinceChange: time.Since(round.StartedAt),
+	}, nil
+}
@@ -0,0 +1,46 @@
+package monitoring
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/uptimefeed"
+	uptimeFeedMocks "github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/uptimefeed/mocks"
+)
+
+func TestSequencerStatusSource(t *testing.T) {
+	chainConfig := generateChainConfig()
+	nodeConfig := generateNodeConfig()
+	feedAddress := new(felt.Felt).SetUint64(0x1234)
+
+	uptimeFeedReader := uptimeFeedMocks.NewUptimeFeedReader(t)
+	uptimeFeedReader.On(
+		"LatestRoundData",
+		mock.Anything, // ctx
+		feedAddress,
+	).Return(uptimefeed.RoundData{RoundData: ocr2.RoundData{
+		RoundID:   3,
+		Answer:    big.NewInt(1),
+		StartedAt: time.Now().Add(-time.Hour),
+	}}, nil).Once()
+
+	factory := NewSequencerStatusSourceFactory(uptimeFeedReader, feedAddress)
+	source, err := factory.NewSource(chainConfig, nodeConfig)
+	require.NoError(t, err)
+	rawEnvelope, err := source.Fetch(context.Background())
+	require.NoError(t, err)
+	envelope, ok := rawEnvelope.(SequencerStatusEnvelope)
+	require.True(t, ok)
+
+	require.False(t, envelope.Up)
+	require.Equal(t, uint32(3), envelope.RoundID)
+	require.Equal(t, feedAddress, envelope.FeedAddress)
+	require.InDelta(t, time.Hour.Seconds(), envelope.SinceChange.Seconds(), 60)
+}
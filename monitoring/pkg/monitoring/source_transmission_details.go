@@ -48,6 +48,7 @@ func (s *transmissionDetailsSourceFactory) NewSource(
 	}
 	return &transmissionDetailsSource{
 		contractAddress,
+		starknetFeedConfig.Signed,
 		s.ocr2Reader,
 	}, nil
 }
@@ -58,6 +59,7 @@ func (s *transmissionDetailsSourceFactory) GetType() string {
 
 type transmissionDetailsSource struct {
 	contractAddress *felt.Felt
+	signed          bool
 	ocr2Reader      ocr2.OCR2Reader
 }
 
@@ -66,7 +68,7 @@ func (s *transmissionDetailsSource) Fetch(ctx context.Context) (interface{}, err
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch latest_round_data: %w", err)
 	}
-	transmissions, err := s.ocr2Reader.NewTransmissionsFromEventsAt(ctx, s.contractAddress, latestRound.BlockNumber)
+	transmissions, err := s.ocr2Reader.NewTransmissionsFromEventsAt(ctx, s.contractAddress, latestRound.BlockNumber, s.signed)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't fetch transmission events: %w", err)
 	}
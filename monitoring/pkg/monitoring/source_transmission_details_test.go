@@ -32,6 +32,7 @@ func TestTransmissionDetailsSource(t *testing.T) {
 		mock.Anything, // ctx
 		contractAddressFelt,
 		blockNumber,
+		false, // signed
 	).Return(
 		[]ocr2.NewTransmissionEvent{
 			{
@@ -0,0 +1,212 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+
+	relayMonitoring "github.com/smartcontractkit/chainlink-common/pkg/monitoring"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+)
+
+// maxProfitabilityBlockRange bounds the blocks scanned in a single fetch, a monitor that fell behind catches up over
+// several polls
+const maxProfitabilityBlockRange = 1000
+
+// TransmitterProfitability is what a transmitter spent and earned on a feed over a range of blocks.
+// Amounts are in FRI (fees) and juels (everything else).
+type TransmitterProfitability struct {
+	Transmitter   string
+	Transmissions uint64
+	// fees paid in STRK, transactions paying in ETH are not included
+	FeesFri *big.Int
+	// fees converted at the juels_per_fee_coin of the transmitted report, comparable to the earnings
+	FeesJuels *big.Int
+	// reimbursement plus the transmission payment, as credited by the aggregator
+	EarnedJuels *big.Int
+}
+
+type TransmitterProfitabilityEnvelope struct {
+	FromBlock, ToBlock uint64
+	Transmitters       []TransmitterProfitability
+}
+
+// NewTransmitterProfitabilitySourceFactory builds a source that joins the NewTransmission events of a feed with the
+// receipts of the transactions that emitted them.
+func NewTransmitterProfitabilitySourceFactory(
+	ocr2Reader ocr2.OCR2Reader,
+) relayMonitoring.SourceFactory {
+	return &transmitterProfitabilitySourceFactory{
+		ocr2Reader,
+	}
+}
+
+type transmitterProfitabilitySourceFactory struct {
+	ocr2Reader ocr2.OCR2Reader
+}
+
+func (s *transmitterProfitabilitySourceFactory) NewSource(
+	_ relayMonitoring.ChainConfig,
+	feedConfig relayMonitoring.FeedConfig,
+) (relayMonitoring.Source, error) {
+	starknetFeedConfig, ok := feedConfig.(StarknetFeedConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected feedConfig to be of type StarknetFeedConfig not %T", feedConfig)
+	}
+	contractAddress, err := starknetutils.HexToFelt(starknetFeedConfig.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	parse := ocr2.ParseNewTransmissionEvent
+	if starknetFeedConfig.Signed {
+		parse = ocr2.ParseSignedNewTransmissionEvent
+	}
+	return &transmitterProfitabilitySource{
+		contractAddress: contractAddress,
+		parse:           parse,
+		ocr2Reader:      s.ocr2Reader,
+	}, nil
+}
+
+func (s *transmitterProfitabilitySourceFactory) GetType() string {
+	return "transmitter profitability"
+}
+
+// transmitterProfitabilitySource returns the transmissions since the previous fetch, the first fetch only records
+// the current block. Fetch is not safe for concurrent use, the monitor polls each source from a single goroutine.
+type transmitterProfitabilitySource struct {
+	contractAddress *felt.Felt
+	// parses the NewTransmission events, depending on whether the feed stores signed answers
+	parse      func(starknetrpc.EmittedEvent) (ocr2.NewTransmissionEvent, error)
+	ocr2Reader ocr2.OCR2Reader
+
+	initialized bool
+	lastBlock   uint64
+}
+
+func (s *transmitterProfitabilitySource) Fetch(ctx context.Context) (interface{}, error) {
+	latest, err := s.ocr2Reader.BaseReader().LatestBlockHeight(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch latest block height: %w", err)
+	}
+	if !s.initialized {
+		s.initialized = true
+		s.lastBlock = latest
+		return TransmitterProfitabilityEnvelope{FromBlock: latest + 1, ToBlock: latest}, nil
+	}
+
+	envelope := TransmitterProfitabilityEnvelope{FromBlock: s.lastBlock + 1, ToBlock: latest}
+	if envelope.ToBlock < envelope.FromBlock {
+		return envelope, nil
+	}
+	if envelope.ToBlock-envelope.FromBlock >= maxProfitabilityBlockRange {
+		envelope.ToBlock = envelope.FromBlock + maxProfitabilityBlockRange - 1
+	}
+
+	events, err := s.fetchTransmissionEvents(ctx, envelope.FromBlock, envelope.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) > 0 {
+		envelope.Transmitters, err = s.aggregate(ctx, events)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// only advance once the whole range was accounted for, a failed fetch is retried on the next poll
+	s.lastBlock = envelope.ToBlock
+	return envelope, nil
+}
+
+func (s *transmitterProfitabilitySource) fetchTransmissionEvents(ctx context.Context, fromBlock, toBlock uint64) (events []starknetrpc.EmittedEvent, err error) {
+	input := starknetrpc.EventsInput{
+		EventFilter: starknetrpc.EventFilter{
+			FromBlock: starknetrpc.WithBlockNumber(fromBlock),
+			ToBlock:   starknetrpc.WithBlockNumber(toBlock),
+			Address:   s.contractAddress,
+			Keys:      [][]*felt.Felt{{starknetutils.GetSelectorFromNameFelt("NewTransmission")}},
+		},
+		ResultPageRequest: starknetrpc.ResultPageRequest{
+			ChunkSize: 100,
+		},
+	}
+	for {
+		chunk, err := s.ocr2Reader.BaseReader().Events(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't fetch transmission events between blocks %d and %d: %w", fromBlock, toBlock, err)
+		}
+		events = append(events, chunk.Events...)
+		if chunk.ContinuationToken == "" {
+			return events, nil
+		}
+		input.ContinuationToken = chunk.ContinuationToken
+	}
+}
+
+func (s *transmitterProfitabilitySource) aggregate(ctx context.Context, events []starknetrpc.EmittedEvent) ([]TransmitterProfitability, error) {
+	billing, err := s.ocr2Reader.BillingDetails(ctx, s.contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch billing details: %w", err)
+	}
+
+	byTransmitter := map[string]*TransmitterProfitability{}
+	feesPaid := map[string]struct{}{}
+	for _, event := range events {
+		transmission, err := s.parse(event)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse transmission event in transaction %s: %w", event.TransactionHash, err)
+		}
+		transmitter := transmission.Transmitter.String()
+		p, ok := byTransmitter[transmitter]
+		if !ok {
+			p = &TransmitterProfitability{
+				Transmitter: transmitter,
+				FeesFri:     new(big.Int),
+				FeesJuels:   new(big.Int),
+				EarnedJuels: new(big.Int),
+			}
+			byTransmitter[transmitter] = p
+		}
+
+		p.Transmissions++
+		earned, err := billing.TransmissionPayment(transmission.Reimbursement)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't compute payment in transaction %s: %w", event.TransactionHash, err)
+		}
+		p.EarnedJuels.Add(p.EarnedJuels, earned)
+
+		// a transaction may transmit several reports, its fee is only counted once
+		if _, ok := feesPaid[event.TransactionHash.String()]; ok {
+			continue
+		}
+		feesPaid[event.TransactionHash.String()] = struct{}{}
+		receipt, err := s.ocr2Reader.BaseReader().TransactionReceipt(ctx, event.TransactionHash)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't fetch receipt of transaction %s: %w", event.TransactionHash, err)
+		}
+		fee, err := starknet.InvokeActualFee(receipt)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read the fee of transaction %s: %w", event.TransactionHash, err)
+		}
+		if fee.Unit != starknetrpc.UnitStrk || fee.Amount == nil {
+			continue
+		}
+		p.FeesFri.Add(p.FeesFri, fee.Amount.BigInt(new(big.Int)))
+		p.FeesJuels.Add(p.FeesJuels, ocr2.FeeInJuels(fee.Amount, transmission.JuelsPerFeeCoin))
+	}
+
+	out := make([]TransmitterProfitability, 0, len(byTransmitter))
+	for _, p := range byTransmitter {
+		out = append(out, *p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Transmitter < out[j].Transmitter })
+	return out, nil
+}
@@ -0,0 +1,150 @@
+package monitoring
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2"
+	ocr2Mocks "github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2/mocks"
+	starknetMocks "github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet/mocks"
+)
+
+func TestTransmitterProfitabilitySource(t *testing.T) {
+	chainConfig := generateChainConfig()
+	feedConfig := generateFeedConfig()
+
+	contractAddress, err := starknetutils.HexToFelt(feedConfig.ContractAddress)
+	require.NoError(t, err)
+	transmitter1 := new(felt.Felt).SetUint64(0x101)
+	transmitter2 := new(felt.Felt).SetUint64(0x102)
+	txA := new(felt.Felt).SetUint64(0xa)
+	txB := new(felt.Felt).SetUint64(0xb)
+
+	baseReader := starknetMocks.NewReader(t)
+	ocr2Reader := ocr2Mocks.NewOCR2Reader(t)
+	ocr2Reader.On("BaseReader").Return(baseReader)
+
+	factory := NewTransmitterProfitabilitySourceFactory(ocr2Reader)
+	source, err := factory.NewSource(chainConfig, feedConfig)
+	require.NoError(t, err)
+
+	// the first fetch only records the current block
+	baseReader.On("LatestBlockHeight", mock.Anything).Return(uint64(100), nil).Once()
+	data, err := source.Fetch(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, data.(TransmitterProfitabilityEnvelope).Transmitters)
+
+	baseReader.On("LatestBlockHeight", mock.Anything).Return(uint64(105), nil).Once()
+	baseReader.On("Events", mock.Anything, mock.MatchedBy(func(input starknetrpc.EventsInput) bool {
+		return *input.FromBlock.Number == 101 && *input.ToBlock.Number == 105 && input.ContinuationToken == ""
+	})).Return(&starknetrpc.EventChunk{
+		Events: []starknetrpc.EmittedEvent{
+			newTransmissionEvent(t, txA, transmitter1, 10),
+			newTransmissionEvent(t, txA, transmitter1, 20),
+		},
+		ContinuationToken: "next",
+	}, nil).Once()
+	baseReader.On("Events", mock.Anything, mock.MatchedBy(func(input starknetrpc.EventsInput) bool {
+		return input.ContinuationToken == "next"
+	})).Return(&starknetrpc.EventChunk{
+		Events: []starknetrpc.EmittedEvent{newTransmissionEvent(t, txB, transmitter2, 5)},
+	}, nil).Once()
+	ocr2Reader.On("BillingDetails", mock.Anything, contractAddress).Return(ocr2.BillingDetails{TransmissionPaymentGJuels: 1}, nil).Once()
+	baseReader.On("TransactionReceipt", mock.Anything, txA).Return(starknetrpc.InvokeTransactionReceipt{
+		ActualFee: starknetrpc.FeePayment{Amount: new(felt.Felt).SetUint64(100), Unit: starknetrpc.UnitStrk},
+	}, nil).Once()
+	// fees paid in ETH are not accounted for
+	baseReader.On("TransactionReceipt", mock.Anything, txB).Return(starknetrpc.InvokeTransactionReceipt{
+		ActualFee: starknetrpc.FeePayment{Amount: new(felt.Felt).SetUint64(50), Unit: starknetrpc.UnitWei},
+	}, nil).Once()
+
+	data, err = source.Fetch(context.Background())
+	require.NoError(t, err)
+	envelope := data.(TransmitterProfitabilityEnvelope)
+	require.Equal(t, uint64(101), envelope.FromBlock)
+	require.Equal(t, uint64(105), envelope.ToBlock)
+	require.Equal(t, []TransmitterProfitability{
+		{
+			Transmitter:   transmitter1.String(),
+			Transmissions: 2,
+			FeesFri:       big.NewInt(100),
+			FeesJuels:     big.NewInt(200), // juels_per_fee_coin = 2
+			EarnedJuels:   big.NewInt(10 + 20 + 2_000_000_000),
+		},
+		{
+			Transmitter:   transmitter2.String(),
+			Transmissions: 1,
+			FeesFri:       big.NewInt(0),
+			FeesJuels:     big.NewInt(0),
+			EarnedJuels:   big.NewInt(5 + 1_000_000_000),
+		},
+	}, envelope.Transmitters)
+
+	// no new blocks
+	baseReader.On("LatestBlockHeight", mock.Anything).Return(uint64(105), nil).Once()
+	data, err = source.Fetch(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, data.(TransmitterProfitabilityEnvelope).Transmitters)
+}
+
+func TestTransmitterProfitabilitySource_Signed(t *testing.T) {
+	feedConfig := generateFeedConfig()
+	feedConfig.Signed = true
+	contractAddress, err := starknetutils.HexToFelt(feedConfig.ContractAddress)
+	require.NoError(t, err)
+
+	baseReader := starknetMocks.NewReader(t)
+	ocr2Reader := ocr2Mocks.NewOCR2Reader(t)
+	ocr2Reader.On("BaseReader").Return(baseReader)
+	source, err := NewTransmitterProfitabilitySourceFactory(ocr2Reader).NewSource(generateChainConfig(), feedConfig)
+	require.NoError(t, err)
+	baseReader.On("LatestBlockHeight", mock.Anything).Return(uint64(100), nil).Once()
+	_, err = source.Fetch(context.Background())
+	require.NoError(t, err)
+
+	// answers of signed feeds are i128s, a value beyond u128 is rejected
+	event := newTransmissionEvent(t, new(felt.Felt).SetUint64(0xa), new(felt.Felt).SetUint64(0x101), 10)
+	event.Data[0] = starknetutils.BigIntToFelt(new(big.Int).Lsh(big.NewInt(1), 128))
+	baseReader.On("LatestBlockHeight", mock.Anything).Return(uint64(101), nil).Once()
+	baseReader.On("Events", mock.Anything, mock.Anything).Return(&starknetrpc.EventChunk{
+		Events: []starknetrpc.EmittedEvent{event},
+	}, nil).Once()
+	ocr2Reader.On("BillingDetails", mock.Anything, contractAddress).Return(ocr2.BillingDetails{}, nil).Once()
+	_, err = source.Fetch(context.Background())
+	require.ErrorContains(t, err, "invalid answer")
+}
+
+func newTransmissionEvent(t *testing.T, txHash, transmitter *felt.Felt, reimbursement uint64) starknetrpc.EmittedEvent {
+	keys, err := starknetutils.HexArrToFelt([]string{
+		starknetutils.GetSelectorFromNameFelt("NewTransmission").String(),
+		"0x1", // round_id
+	})
+	require.NoError(t, err)
+	data, err := starknetutils.HexArrToFelt([]string{
+		"0x63", // answer
+		"0x1",  // observation_timestamp
+		"0x10000000000000000000000000000000000000000000000000000000000", // observers
+		"0x1",  // len(observations)
+		"0x63", // observation
+		"0x2",  // juels_per_fee_coin
+		"0x1",  // gas_price
+		"0x485341c18461d70eac6ded4b8b17147f173308ddd56216a86f9ec4d994453", // config_digest
+		"0x1", // epoch_and_round
+		new(felt.Felt).SetUint64(reimbursement).String(),
+	})
+	require.NoError(t, err)
+	return starknetrpc.EmittedEvent{
+		Event: starknetrpc.Event{
+			Keys: append(keys, transmitter),
+			Data: data,
+		},
+		TransactionHash: txHash,
+	}
+}
@@ -0,0 +1,165 @@
+package ops
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/median"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/confighelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// OCR2ConfigFromContractConfig decodes a contract config (as returned by the relayer's ParseConfigSetEvent) into the
+// gauntlet OCR2Config format.
+// The config encryption public keys and the secret are not part of the on-chain config and are left empty.
+func OCR2ConfigFromContractConfig(cfg types.ContractConfig) (*OCR2Config, error) {
+	publicConfig, err := confighelper.PublicConfigFromContractConfig(true, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decode offchain config: %w", err)
+	}
+
+	pluginConfig, err := median.DecodeOffchainConfig(publicConfig.ReportingPluginConfig)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decode reporting plugin config: %w", err)
+	}
+
+	var signers, transmitters, offchainPublicKeys, peerIDs []string
+	for _, identity := range publicConfig.OracleIdentities {
+		signers = append(signers, "0x"+hex.EncodeToString(identity.OnchainPublicKey))
+		transmitters = append(transmitters, string(identity.TransmitAccount))
+		offchainPublicKeys = append(offchainPublicKeys, hex.EncodeToString(identity.OffchainPublicKey[:]))
+		peerIDs = append(peerIDs, identity.PeerID)
+	}
+
+	var onchainConfig string
+	if len(cfg.OnchainConfig) > 0 {
+		onchainConfig = "0x" + hex.EncodeToString(cfg.OnchainConfig)
+	}
+
+	return &OCR2Config{
+		F:            publicConfig.F,
+		Signers:      signers,
+		Transmitters: transmitters,
+		// computed by the aggregator from its min and max answer
+		OnchainConfig: onchainConfig,
+		OffchainConfig: &OffchainConfig{
+			DeltaProgressNanoseconds: publicConfig.DeltaProgress.Nanoseconds(),
+			DeltaResendNanoseconds:   publicConfig.DeltaResend.Nanoseconds(),
+			DeltaRoundNanoseconds:    publicConfig.DeltaRound.Nanoseconds(),
+			DeltaGraceNanoseconds:    int(publicConfig.DeltaGrace.Nanoseconds()),
+			DeltaStageNanoseconds:    publicConfig.DeltaStage.Nanoseconds(),
+			RMax:                     int(publicConfig.RMax),
+			S:                        publicConfig.S,
+			OffchainPublicKeys:       offchainPublicKeys,
+			PeerIDs:                  peerIDs,
+			ReportingPluginConfig: &ReportingPluginConfig{
+				AlphaReportInfinite: pluginConfig.AlphaReportInfinite,
+				AlphaReportPpb:      int(pluginConfig.AlphaReportPPB),
+				AlphaAcceptInfinite: pluginConfig.AlphaAcceptInfinite,
+				AlphaAcceptPpb:      int(pluginConfig.AlphaAcceptPPB),
+				DeltaCNanoseconds:   int(pluginConfig.DeltaC.Nanoseconds()),
+			},
+			MaxDurationQueryNanoseconds:                        int(publicConfig.MaxDurationQuery.Nanoseconds()),
+			MaxDurationObservationNanoseconds:                  int(publicConfig.MaxDurationObservation.Nanoseconds()),
+			MaxDurationReportNanoseconds:                       int(publicConfig.MaxDurationReport.Nanoseconds()),
+			MaxDurationShouldAcceptFinalizedReportNanoseconds:  int(publicConfig.MaxDurationShouldAcceptFinalizedReport.Nanoseconds()),
+			MaxDurationShouldTransmitAcceptedReportNanoseconds: int(publicConfig.MaxDurationShouldTransmitAcceptedReport.Nanoseconds()),
+		},
+		OffchainConfigVersion: int(cfg.OffchainConfigVersion),
+	}, nil
+}
+
+// OCR2ConfigChange is a single field that differs between two configs
+type OCR2ConfigChange struct {
+	Field string
+	From  any
+	To    any
+}
+
+func (c OCR2ConfigChange) String() string {
+	return fmt.Sprintf("%s: %v -> %v", c.Field, c.From, c.To)
+}
+
+// DiffOCR2Config lists the fields that changed between the current and the proposed config.
+// Secrets and config public keys are skipped since they cannot be recovered from the chain. The onchain config is only
+// compared if it is set on both configs, as set_config computes it on-chain.
+func DiffOCR2Config(current, proposed *OCR2Config) (changes []OCR2ConfigChange) {
+	add := func(field string, from, to any) {
+		if !reflect.DeepEqual(from, to) {
+			changes = append(changes, OCR2ConfigChange{Field: field, From: from, To: to})
+		}
+	}
+	duration := func(ns int64) time.Duration {
+		return time.Duration(ns)
+	}
+
+	add("f", current.F, proposed.F)
+	diffList(&changes, "signers", normalizeHex(current.Signers), normalizeHex(proposed.Signers))
+	diffList(&changes, "transmitters", normalizeHex(current.Transmitters), normalizeHex(proposed.Transmitters))
+	if current.OnchainConfig != "" && proposed.OnchainConfig != "" {
+		add("onchainConfig", current.OnchainConfig, proposed.OnchainConfig)
+	}
+	add("offchainConfigVersion", current.OffchainConfigVersion, proposed.OffchainConfigVersion)
+
+	c, p := current.OffchainConfig, proposed.OffchainConfig
+	if c == nil || p == nil {
+		add("offchainConfig", c, p)
+		return changes
+	}
+	add("offchainConfig.deltaProgress", duration(c.DeltaProgressNanoseconds), duration(p.DeltaProgressNanoseconds))
+	add("offchainConfig.deltaResend", duration(c.DeltaResendNanoseconds), duration(p.DeltaResendNanoseconds))
+	add("offchainConfig.deltaRound", duration(c.DeltaRoundNanoseconds), duration(p.DeltaRoundNanoseconds))
+	add("offchainConfig.deltaGrace", duration(int64(c.DeltaGraceNanoseconds)), duration(int64(p.DeltaGraceNanoseconds)))
+	add("offchainConfig.deltaStage", duration(c.DeltaStageNanoseconds), duration(p.DeltaStageNanoseconds))
+	add("offchainConfig.rMax", c.RMax, p.RMax)
+	add("offchainConfig.s", c.S, p.S)
+	diffList(&changes, "offchainConfig.offchainPublicKeys", c.OffchainPublicKeys, p.OffchainPublicKeys)
+	diffList(&changes, "offchainConfig.peerIds", c.PeerIDs, p.PeerIDs)
+	add("offchainConfig.maxDurationQuery", duration(int64(c.MaxDurationQueryNanoseconds)), duration(int64(p.MaxDurationQueryNanoseconds)))
+	add("offchainConfig.maxDurationObservation", duration(int64(c.MaxDurationObservationNanoseconds)), duration(int64(p.MaxDurationObservationNanoseconds)))
+	add("offchainConfig.maxDurationReport", duration(int64(c.MaxDurationReportNanoseconds)), duration(int64(p.MaxDurationReportNanoseconds)))
+	add("offchainConfig.maxDurationShouldAcceptFinalizedReport", duration(int64(c.MaxDurationShouldAcceptFinalizedReportNanoseconds)), duration(int64(p.MaxDurationShouldAcceptFinalizedReportNanoseconds)))
+	add("offchainConfig.maxDurationShouldTransmitAcceptedReport", duration(int64(c.MaxDurationShouldTransmitAcceptedReportNanoseconds)), duration(int64(p.MaxDurationShouldTransmitAcceptedReportNanoseconds)))
+
+	cr, pr := c.ReportingPluginConfig, p.ReportingPluginConfig
+	if cr == nil || pr == nil {
+		add("offchainConfig.reportingPluginConfig", cr, pr)
+		return changes
+	}
+	add("offchainConfig.reportingPluginConfig.alphaReportInfinite", cr.AlphaReportInfinite, pr.AlphaReportInfinite)
+	add("offchainConfig.reportingPluginConfig.alphaReportPpb", cr.AlphaReportPpb, pr.AlphaReportPpb)
+	add("offchainConfig.reportingPluginConfig.alphaAcceptInfinite", cr.AlphaAcceptInfinite, pr.AlphaAcceptInfinite)
+	add("offchainConfig.reportingPluginConfig.alphaAcceptPpb", cr.AlphaAcceptPpb, pr.AlphaAcceptPpb)
+	add("offchainConfig.reportingPluginConfig.deltaC", duration(int64(cr.DeltaCNanoseconds)), duration(int64(pr.DeltaCNanoseconds)))
+
+	return changes
+}
+
+// diffList compares oracle lists index by index so a single rotated key shows up as a single change
+func diffList(changes *[]OCR2ConfigChange, field string, from, to []string) {
+	for i := 0; i < max(len(from), len(to)); i++ {
+		var f, t string
+		if i < len(from) {
+			f = from[i]
+		}
+		if i < len(to) {
+			t = to[i]
+		}
+		if f != t {
+			*changes = append(*changes, OCR2ConfigChange{Field: fmt.Sprintf("%s[%d]", field, i), From: f, To: t})
+		}
+	}
+}
+
+// normalizeHex strips the 0x prefix and leading zeros so padded and unpadded felts compare equal
+func normalizeHex(values []string) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		v = strings.TrimLeft(strings.TrimPrefix(strings.ToLower(v), "0x"), "0")
+		out[i] = "0x" + v
+	}
+	return out
+}
@@ -0,0 +1,108 @@
+package ops
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/median"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/confighelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOCR2ConfigFromContractConfig(t *testing.T) {
+	var oracles []confighelper.OracleIdentityExtra
+	for i := 0; i < 4; i++ {
+		var offchainKey types.OffchainPublicKey
+		offchainKey[31] = byte(i)
+		signer := make([]byte, 32)
+		signer[31] = byte(i)
+		encryptionKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		oracles = append(oracles, confighelper.OracleIdentityExtra{
+			OracleIdentity: confighelper.OracleIdentity{
+				OffchainPublicKey: offchainKey,
+				OnchainPublicKey:  signer,
+				PeerID:            fmt.Sprintf("peer-%d", i),
+				TransmitAccount:   types.Account(fmt.Sprintf("0x%x", 100+i)),
+			},
+			ConfigEncryptionPublicKey: types.ConfigEncryptionPublicKey(encryptionKey.PublicKey().Bytes()),
+		})
+	}
+	pluginConfig := median.OffchainConfig{
+		AlphaReportPPB: 1000,
+		AlphaAcceptPPB: 500,
+		DeltaC:         time.Minute,
+	}
+
+	signers, transmitters, f, _, offchainConfigVersion, offchainConfig, err := confighelper.ContractSetConfigArgsForTests(
+		10*time.Second, 20*time.Second, 5*time.Second, time.Second, 30*time.Second,
+		5, []int{1, 1, 2, 2}, oracles, pluginConfig.Encode(), nil,
+		time.Second, 2*time.Second, 3*time.Second, 4*time.Second, 5*time.Second,
+		1, nil,
+	)
+	require.NoError(t, err)
+
+	onchainConfig := []byte{0x1}
+	cfg, err := OCR2ConfigFromContractConfig(types.ContractConfig{
+		Signers:               signers,
+		Transmitters:          transmitters,
+		F:                     f,
+		OnchainConfig:         onchainConfig,
+		OffchainConfigVersion: offchainConfigVersion,
+		OffchainConfig:        offchainConfig,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, cfg.F)
+	assert.Equal(t, "0x01", cfg.OnchainConfig)
+	assert.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000000003", cfg.Signers[3])
+	assert.Equal(t, "0x67", cfg.Transmitters[3])
+	assert.Equal(t, int(offchainConfigVersion), cfg.OffchainConfigVersion)
+
+	offchain := cfg.OffchainConfig
+	assert.Equal(t, int64(10*time.Second), offchain.DeltaProgressNanoseconds)
+	assert.Equal(t, int64(20*time.Second), offchain.DeltaResendNanoseconds)
+	assert.Equal(t, int64(5*time.Second), offchain.DeltaRoundNanoseconds)
+	assert.Equal(t, int(time.Second), offchain.DeltaGraceNanoseconds)
+	assert.Equal(t, int64(30*time.Second), offchain.DeltaStageNanoseconds)
+	assert.Equal(t, 5, offchain.RMax)
+	assert.Equal(t, []int{1, 1, 2, 2}, offchain.S)
+	assert.Equal(t, "0000000000000000000000000000000000000000000000000000000000000002", offchain.OffchainPublicKeys[2])
+	assert.Equal(t, []string{"peer-0", "peer-1", "peer-2", "peer-3"}, offchain.PeerIDs)
+	assert.Equal(t, int(time.Second), offchain.MaxDurationQueryNanoseconds)
+	assert.Equal(t, int(5*time.Second), offchain.MaxDurationShouldTransmitAcceptedReportNanoseconds)
+	assert.Equal(t, &ReportingPluginConfig{
+		AlphaReportPpb:    1000,
+		AlphaAcceptPpb:    500,
+		DeltaCNanoseconds: int(time.Minute),
+	}, offchain.ReportingPluginConfig)
+
+	t.Run("diff", func(t *testing.T) {
+		assert.Empty(t, DiffOCR2Config(cfg, cfg))
+
+		proposed := *cfg
+		proposedOffchain := *cfg.OffchainConfig
+		proposedPlugin := *cfg.OffchainConfig.ReportingPluginConfig
+		proposedOffchain.ReportingPluginConfig = &proposedPlugin
+		proposed.OffchainConfig = &proposedOffchain
+
+		// padded transmitters and a missing onchain config are not changes
+		proposed.Transmitters = []string{"0x0064", "0x0065", "0x0066", "0x0067"}
+		proposed.OnchainConfig = ""
+		proposed.Signers = append([]string{}, cfg.Signers...)
+		proposed.Signers[1] = "0x05"
+		proposedOffchain.DeltaRoundNanoseconds = int64(10 * time.Second)
+		proposedPlugin.AlphaReportPpb = 2000
+
+		changes := DiffOCR2Config(cfg, &proposed)
+		require.Len(t, changes, 3)
+		assert.Equal(t, "signers[1]: 0x1 -> 0x5", changes[0].String())
+		assert.Equal(t, "offchainConfig.deltaRound: 5s -> 10s", changes[1].String())
+		assert.Equal(t, "offchainConfig.reportingPluginConfig.alphaReportPpb: 1000 -> 2000", changes[2].String())
+	})
+}
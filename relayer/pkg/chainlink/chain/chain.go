@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"math/rand"
+	"slices"
 	"strconv"
+	"sync"
 
 	"github.com/pelletier/go-toml/v2"
 
@@ -19,6 +22,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/config"
 	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/db"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/keystore"
 	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/txm"
 	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
 )
@@ -28,9 +32,14 @@ type Chain interface {
 
 	ID() string
 	Config() config.Config
+	// UpdateConfig applies a new config without restarting the chain, see [config.Reloadable.Update]
+	UpdateConfig(cfg *config.TOMLConfig) error
 
 	TxManager() txm.TxManager
+	// KeyRotator rotates the keys of the accounts sending through the TxManager
+	KeyRotator() txm.KeyRotator
 	Reader() (starknet.Reader, error)
+	ChainClient() (starknet.ChainClient, error)
 }
 
 type ChainOpts struct {
@@ -61,16 +70,35 @@ var _ Chain = (*chain)(nil)
 type chain struct {
 	utils.StartStopOnce
 	id   string
-	cfg  *config.TOMLConfig
+	cfg  *config.Reloadable
 	lggr logger.Logger
 	txm  txm.StarkTXM
+	// shared by the OCR2 providers, reset on config updates
+	reader *reader
+
+	// rate limiters by node name, shared by all clients of a node
+	limitersLock sync.Mutex
+	limiters     map[string]*starknet.RateLimiter
 }
 
 func NewChain(cfg *config.TOMLConfig, opts ChainOpts) (Chain, error) {
 	if !cfg.IsEnabled() {
 		return nil, fmt.Errorf("cannot create new chain with ID %s: chain is disabled", *cfg.ChainID)
 	}
-	c, err := newChain(*cfg.ChainID, cfg, opts.KeyStore, opts.Logger)
+	ks := opts.KeyStore
+	rs, err := cfg.RemoteSignerConfig()
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote signer config: %w", err)
+	}
+	if rs != nil {
+		remoteKs, err := keystore.NewRemoteKeystore(*rs)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't create remote signer keystore: %w", err)
+		}
+		opts.Logger.Infow("signing transactions with remote signer", "url", rs.URL)
+		ks = remoteKs
+	}
+	c, err := newChain(*cfg.ChainID, cfg, ks, opts.Logger)
 	if err != nil {
 		return nil, err
 	}
@@ -80,13 +108,17 @@ func NewChain(cfg *config.TOMLConfig, opts ChainOpts) (Chain, error) {
 func newChain(id string, cfg *config.TOMLConfig, loopKs loop.Keystore, lggr logger.Logger) (*chain, error) {
 	lggr = logger.With(lggr, "starknetChainID", id)
 	ch := &chain{
-		id:   id,
-		cfg:  cfg,
-		lggr: logger.Named(lggr, "Chain"),
+		id:       id,
+		cfg:      config.NewReloadable(cfg),
+		lggr:     logger.Named(lggr, "Chain"),
+		limiters: map[string]*starknet.RateLimiter{},
 	}
 
+	ch.reader = &reader{client: utils.NewLazyLoad(ch.getClient)}
+
+	// the TXM sends through the nodes with the send role
 	getClient := func() (*starknet.Client, error) {
-		return ch.getClient()
+		return ch.getClientWithRole(config.NodeRoleSend)
 	}
 
 	getFeederClient := func() (*starknet.FeederClient, error) {
@@ -94,7 +126,7 @@ func newChain(id string, cfg *config.TOMLConfig, loopKs loop.Keystore, lggr logg
 	}
 
 	var err error
-	ch.txm, err = txm.New(lggr, loopKs, cfg, getClient, getFeederClient)
+	ch.txm, err = txm.New(lggr, loopKs, ch.cfg, getClient, getFeederClient)
 	if err != nil {
 		return nil, err
 	}
@@ -110,12 +142,36 @@ func (c *chain) Config() config.Config {
 	return c.cfg
 }
 
+func (c *chain) UpdateConfig(cfg *config.TOMLConfig) error {
+	if err := c.cfg.Update(cfg); err != nil {
+		return err
+	}
+	// cached clients may point to removed nodes or use the previous request timeout
+	c.reader.client.Reset()
+	c.txm.ResetClients()
+	c.lggr.Infow("config updated", "nodes", len(cfg.Nodes))
+	return nil
+}
+
 func (c *chain) TxManager() txm.TxManager {
 	return c.txm
 }
 
+func (c *chain) KeyRotator() txm.KeyRotator {
+	return c.txm
+}
+
+// Reader returns a reader that follows config updates, the node is picked on first use and after each update
 func (c *chain) Reader() (starknet.Reader, error) {
-	return c.getClient()
+	return c.reader, nil
+}
+
+func (c *chain) ChainClient() (starknet.ChainClient, error) {
+	client, err := c.getClient()
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
 }
 
 func (c *chain) ChainID() string {
@@ -123,27 +179,37 @@ func (c *chain) ChainID() string {
 }
 
 func (c *chain) getFeederClient() *starknet.FeederClient {
-	return starknet.NewFeederClient(c.cfg.FeederURL.String())
+	return starknet.NewFeederClient(c.cfg.Current().FeederURL.String()).WithChainID(c.id)
 }
 
-// getClient returns a client, randomly selecting one from available and valid nodes
+// getClient returns a client for reads, see getClientWithRole
 func (c *chain) getClient() (*starknet.Client, error) {
+	return c.getClientWithRole(config.NodeRoleRead)
+}
+
+// getClientWithRole returns a client of a node with the role, randomly selecting one from available and valid nodes
+// in proportion to their weight
+func (c *chain) getClientWithRole(role string) (*starknet.Client, error) {
 	var node db.Node
 	var client *starknet.Client
 	nodes, err := c.cfg.ListNodes()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get nodes: %w", err)
 	}
+	nodes = slices.DeleteFunc(nodes, func(n db.Node) bool {
+		return n.Role != role && n.Role != config.NodeRoleBoth
+	})
 	if len(nodes) == 0 {
-		return nil, errors.New("no nodes available")
+		return nil, fmt.Errorf("no nodes available with the %s role", role)
 	}
-	// #nosec
-	index := rand.Perm(len(nodes)) // list of node indexes to try
+	index := weightedOrder(nodes) // list of node indexes to try
 	timeout := c.cfg.RequestTimeout()
 	for _, i := range index {
 		node = nodes[i]
 		// create client and check
-		client, err = starknet.NewClient(node.ChainID, node.URL, node.APIKey, c.lggr, &timeout)
+		client, err = starknet.NewClient(node.ChainID, node.URL, node.APIKey, c.lggr, &timeout,
+			starknet.WithHeaders(node.Headers), starknet.WithRateLimiter(c.rateLimiter(node)),
+			starknet.WithRetryPolicy(c.cfg.RetryPolicy()), starknet.WithNodeName(node.Name))
 		// if error, try another node
 		if err != nil {
 			c.lggr.Warnw("failed to create node", "name", node.Name, "starknet-url", node.URL, "err", err.Error())
@@ -156,10 +222,57 @@ func (c *chain) getClient() (*starknet.Client, error) {
 	if client == nil {
 		return nil, errors.New("no node valid nodes available")
 	}
-	c.lggr.Debugw("Created client", "name", node.Name, "starknet-url", node.URL)
+	c.lggr.Debugw("Created client", "name", node.Name, "starknet-url", node.URL, "role", role)
 	return client, nil
 }
 
+// rateLimiter returns the limiter of a node, nil if its requests are not limited. Limiters outlive the clients, so
+// that the limit holds across clients, and are replaced when the limit of the node is changed.
+func (c *chain) rateLimiter(node db.Node) *starknet.RateLimiter {
+	c.limitersLock.Lock()
+	defer c.limitersLock.Unlock()
+	if node.RateLimit <= 0 {
+		delete(c.limiters, node.Name)
+		return nil
+	}
+	if l, ok := c.limiters[node.Name]; ok && l.Rate() == node.RateLimit {
+		return l
+	}
+	// bursts of up to a second of requests
+	l := starknet.NewRateLimiter(node.RateLimit, int(math.Ceil(node.RateLimit)))
+	c.limiters[node.Name] = l
+	return l
+}
+
+// weightedOrder returns the indexes of the nodes in random order, nodes with a higher weight are more likely to come
+// first
+func weightedOrder(nodes []db.Node) []int {
+	weight := func(n db.Node) int {
+		return max(int(n.Weight), 1)
+	}
+	remaining := make([]int, len(nodes))
+	total := 0
+	for i, n := range nodes {
+		remaining[i] = i
+		total += weight(n)
+	}
+	order := make([]int, 0, len(nodes))
+	for len(remaining) > 0 {
+		// #nosec
+		r := rand.Intn(total)
+		for j, i := range remaining {
+			if r < weight(nodes[i]) {
+				order = append(order, i)
+				total -= weight(nodes[i])
+				remaining = append(remaining[:j], remaining[j+1:]...)
+				break
+			}
+			r -= weight(nodes[i])
+		}
+	}
+	return order
+}
+
 func (c *chain) Start(ctx context.Context) error {
 	return c.StartOnce("Chain", func() error {
 		return c.txm.Start(ctx)
@@ -211,13 +324,14 @@ func (c *chain) LatestHead(ctx context.Context) (types.Head, error) {
 
 // ChainService interface
 func (c *chain) GetChainStatus(ctx context.Context) (types.ChainStatus, error) {
-	toml, err := c.cfg.TOMLString()
+	cfg := c.cfg.Current()
+	toml, err := cfg.TOMLString()
 	if err != nil {
 		return types.ChainStatus{}, err
 	}
 	return types.ChainStatus{
 		ID:      c.id,
-		Enabled: c.cfg.IsEnabled(),
+		Enabled: cfg.IsEnabled(),
 		Config:  toml,
 	}, nil
 }
@@ -237,14 +351,15 @@ func (c *chain) SendTx(ctx context.Context, from, to string, amount *big.Int, ba
 // TODO BCF-2602 statuses are static for non-evm chain and should be dynamic
 func (c *chain) listNodeStatuses(start, end int) ([]types.NodeStatus, int, error) {
 	stats := make([]types.NodeStatus, 0)
-	total := len(c.cfg.Nodes)
+	allNodes := c.cfg.Current().Nodes
+	total := len(allNodes)
 	if start >= total {
 		return stats, total, chains.ErrOutOfRange
 	}
 	if end <= 0 || end > total {
 		end = total
 	}
-	nodes := c.cfg.Nodes[start:end]
+	nodes := allNodes[start:end]
 	for _, node := range nodes {
 		stat, err := nodeStatus(node, c.ChainID())
 		if err != nil {
@@ -0,0 +1,109 @@
+package starknet
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/config"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	stkcfg "github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/config"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/db"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/keystore"
+)
+
+// newNode starts a node answering every request with its block number
+func newNode(t *testing.T, name, role, blockNumber string) *stkcfg.Node {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, name, r.Header.Get("X-Node"))
+		_, err := w.Write([]byte(`{"jsonrpc": "2.0", "id": 1, "result": ` + blockNumber + `}`))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+	return &stkcfg.Node{
+		Name:    &name,
+		URL:     config.MustParseURL(server.URL),
+		Headers: map[string]string{"X-Node": name},
+		Role:    &role,
+	}
+}
+
+func TestChain_GetClientWithRole(t *testing.T) {
+	chainID := "SN_SEPOLIA"
+	cfg := &stkcfg.TOMLConfig{
+		ChainID: &chainID,
+		Nodes: stkcfg.Nodes{
+			newNode(t, "public", stkcfg.NodeRoleRead, "1"),
+			newNode(t, "private", stkcfg.NodeRoleSend, "2"),
+		},
+	}
+	cfg.Chain.SetDefaults()
+	require.NoError(t, cfg.ValidateConfig())
+
+	c, err := newChain(chainID, cfg, keystore.NewMemoryKeystore(), logger.Test(t))
+	require.NoError(t, err)
+	ctx := tests.Context(t)
+
+	for i := 0; i < 5; i++ {
+		reader, err := c.getClient()
+		require.NoError(t, err)
+		height, err := reader.LatestBlockHeight(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), height)
+
+		sender, err := c.getClientWithRole(stkcfg.NodeRoleSend)
+		require.NoError(t, err)
+		height, err = sender.LatestBlockHeight(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), height)
+	}
+}
+
+func TestChain_UpdateConfig(t *testing.T) {
+	chainID := "SN_SEPOLIA"
+	newConfig := func(nodes ...*stkcfg.Node) *stkcfg.TOMLConfig {
+		cfg := &stkcfg.TOMLConfig{ChainID: &chainID, Nodes: nodes}
+		cfg.Chain.SetDefaults()
+		return cfg
+	}
+	c, err := newChain(chainID, newConfig(newNode(t, "a", stkcfg.NodeRoleBoth, "1")), keystore.NewMemoryKeystore(), logger.Test(t))
+	require.NoError(t, err)
+	ctx := tests.Context(t)
+
+	// readers handed out before the update switch to the new nodes
+	reader, err := c.Reader()
+	require.NoError(t, err)
+	height, err := reader.LatestBlockHeight(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), height)
+
+	require.NoError(t, c.UpdateConfig(newConfig(newNode(t, "b", stkcfg.NodeRoleBoth, "3"))))
+	height, err = reader.LatestBlockHeight(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), height)
+
+	// invalid configs are rejected and keep the current nodes
+	assert.Error(t, c.UpdateConfig(newConfig()))
+	height, err = reader.LatestBlockHeight(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), height)
+}
+
+func TestWeightedOrder(t *testing.T) {
+	nodes := []db.Node{{Name: "a", Weight: 1}, {Name: "b", Weight: 9}, {Name: "c"}}
+	first := map[int]int{}
+	for i := 0; i < 1000; i++ {
+		order := weightedOrder(nodes)
+		assert.ElementsMatch(t, []int{0, 1, 2}, order)
+		first[order[0]]++
+	}
+	// b is picked first 9 times out of 11
+	assert.Greater(t, first[1], 700)
+	assert.Positive(t, first[0])
+	assert.Positive(t, first[2])
+}
@@ -0,0 +1,92 @@
+package starknet
+
+import (
+	"context"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+)
+
+var _ starknet.Reader = (*reader)(nil)
+
+// reader resolves the read client on every call, so that long-lived readers like the ones of the OCR2 providers follow
+// config updates. The client is cached until the chain config changes.
+type reader struct {
+	client *utils.LazyLoad[*starknet.Client]
+}
+
+func (r *reader) CallContract(ctx context.Context, ops starknet.CallOps) ([]*felt.Felt, error) {
+	client, err := r.client.Get()
+	if err != nil {
+		return nil, err
+	}
+	return client.CallContract(ctx, ops)
+}
+
+func (r *reader) LatestBlockHeight(ctx context.Context) (uint64, error) {
+	client, err := r.client.Get()
+	if err != nil {
+		return 0, err
+	}
+	return client.LatestBlockHeight(ctx)
+}
+
+func (r *reader) BlockWithTxHashes(ctx context.Context, blockID starknetrpc.BlockID) (*starknetrpc.Block, error) {
+	client, err := r.client.Get()
+	if err != nil {
+		return nil, err
+	}
+	return client.BlockWithTxHashes(ctx, blockID)
+}
+
+func (r *reader) Call(ctx context.Context, call starknetrpc.FunctionCall, blockID starknetrpc.BlockID) ([]*felt.Felt, error) {
+	client, err := r.client.Get()
+	if err != nil {
+		return nil, err
+	}
+	return client.Call(ctx, call, blockID)
+}
+
+func (r *reader) Events(ctx context.Context, input starknetrpc.EventsInput) (*starknetrpc.EventChunk, error) {
+	client, err := r.client.Get()
+	if err != nil {
+		return nil, err
+	}
+	return client.Events(ctx, input)
+}
+
+func (r *reader) TransactionByHash(ctx context.Context, hash *felt.Felt) (starknetrpc.Transaction, error) {
+	client, err := r.client.Get()
+	if err != nil {
+		return nil, err
+	}
+	return client.TransactionByHash(ctx, hash)
+}
+
+func (r *reader) TransactionReceipt(ctx context.Context, hash *felt.Felt) (starknetrpc.TransactionReceipt, error) {
+	client, err := r.client.Get()
+	if err != nil {
+		return nil, err
+	}
+	return client.TransactionReceipt(ctx, hash)
+}
+
+func (r *reader) AccountNonce(ctx context.Context, accountAddress *felt.Felt) (*felt.Felt, error) {
+	client, err := r.client.Get()
+	if err != nil {
+		return nil, err
+	}
+	return client.AccountNonce(ctx, accountAddress)
+}
+
+func (r *reader) StorageAt(ctx context.Context, contractAddress *felt.Felt, variable string) (*felt.Felt, error) {
+	client, err := r.client.Get()
+	if err != nil {
+		return nil, err
+	}
+	return client.StorageAt(ctx, contractAddress, variable)
+}
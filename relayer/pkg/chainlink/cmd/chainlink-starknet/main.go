@@ -2,12 +2,15 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/hashicorp/go-plugin"
 	"github.com/pelletier/go-toml/v2"
 
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/loop"
 	"github.com/smartcontractkit/chainlink-common/pkg/types/core"
 
@@ -56,28 +59,115 @@ type pluginRelayer struct {
 // [github.com/smartcontractkit/chainlink-common/pkg/loop.PluginRelayer]
 // loopKs must be an implementation that can construct a starknet keystore adapter
 // [github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/txm.NewKeystoreAdapter]
+// If CL_STARKNET_CONFIG_FILE is set, changes of that file are applied to the chains at runtime, see configWatcher.
 func (c *pluginRelayer) NewRelayer(ctx context.Context, config string, loopKs loop.Keystore, capRegistry core.CapabilitiesRegistry) (loop.Relayer, error) {
-	d := toml.NewDecoder(strings.NewReader(config))
-	d.DisallowUnknownFields()
-	var cfg struct {
-		Starknet stkcfg.TOMLConfig
-	}
-	if err := d.Decode(&cfg); err != nil {
-		return nil, fmt.Errorf("failed to decode config toml: %w:\n\t%s", err, config)
+	cfgs, err := decodeConfigs(config)
+	if err != nil {
+		return nil, err
 	}
 
-	opts := starkchain.ChainOpts{
-		Logger:   c.Logger,
-		KeyStore: loopKs,
+	if len(cfgs) == 1 {
+		opts := starkchain.ChainOpts{
+			Logger:   c.Logger,
+			KeyStore: loopKs,
+		}
+
+		chain, err := starkchain.NewChain(cfgs[0], opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create chain: %w", err)
+		}
+		r := pkgstarknet.NewRelayer(c.Logger, chain, capRegistry)
+
+		c.SubService(r)
+		if err := c.watchConfig(ctx, []starkchain.Chain{chain}); err != nil {
+			return nil, err
+		}
+
+		return r, nil
 	}
 
-	chain, err := starkchain.NewChain(&cfg.Starknet, opts)
+	// every chain gets its own TXM and node pool, the keystore is shared as starknet keys are not bound to a chain
+	var chains []starkchain.Chain
+	for _, cfg := range cfgs {
+		if !cfg.IsEnabled() {
+			continue
+		}
+		opts := starkchain.ChainOpts{
+			Logger:   logger.Named(c.Logger, *cfg.ChainID),
+			KeyStore: loopKs,
+		}
+		chain, err := starkchain.NewChain(cfg, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create chain %s: %w", *cfg.ChainID, err)
+		}
+		chains = append(chains, chain)
+	}
+	r, err := pkgstarknet.NewMultiRelayer(c.Logger, chains, capRegistry)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create chain: %w", err)
+		return nil, fmt.Errorf("failed to create relayer: %w", err)
 	}
-	r := pkgstarknet.NewRelayer(c.Logger, chain, capRegistry)
 
 	c.SubService(r)
+	if err := c.watchConfig(ctx, chains); err != nil {
+		return nil, err
+	}
 
 	return r, nil
 }
+
+// watchConfig starts applying the config file named by CL_STARKNET_CONFIG_FILE to the chains, if set
+func (c *pluginRelayer) watchConfig(ctx context.Context, chains []starkchain.Chain) error {
+	path := os.Getenv(configFileEnv)
+	if path == "" {
+		return nil
+	}
+	w := newConfigWatcher(path, configWatchPeriod, chains, c.Logger)
+	if err := w.Start(ctx); err != nil {
+		return fmt.Errorf("failed to watch config file: %w", err)
+	}
+	c.SubService(w)
+	c.Logger.Infow("watching config file", "path", path)
+	return nil
+}
+
+// decodeConfigs accepts both a single [Starknet] table and a list of [[Starknet]] tables, one per chain
+func decodeConfigs(config string) (stkcfg.TOMLConfigs, error) {
+	var raw map[string]any
+	if err := toml.Unmarshal([]byte(config), &raw); err != nil {
+		return nil, fmt.Errorf("failed to decode config toml: %w:\n\t%s", err, config)
+	}
+
+	var cfgs stkcfg.TOMLConfigs
+	d := toml.NewDecoder(strings.NewReader(config))
+	d.DisallowUnknownFields()
+	if _, ok := raw["Starknet"].([]any); !ok {
+		var cfg struct {
+			Starknet stkcfg.TOMLConfig
+		}
+		if err := d.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to decode config toml: %w:\n\t%s", err, config)
+		}
+		cfgs = stkcfg.TOMLConfigs{&cfg.Starknet}
+	} else {
+		var cfg struct {
+			Starknet stkcfg.TOMLConfigs
+		}
+		if err := d.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to decode config toml: %w:\n\t%s", err, config)
+		}
+		cfgs = cfg.Starknet
+	}
+
+	if len(cfgs) == 0 {
+		return nil, errors.New("no starknet chain configured")
+	}
+	if err := cfgs.ValidateConfig(); err != nil {
+		return nil, fmt.Errorf("invalid starknet chains: %w", err)
+	}
+	for _, c := range cfgs {
+		if err := c.ValidateConfig(); err != nil {
+			return nil, fmt.Errorf("invalid starknet chain: %w", err)
+		}
+	}
+	return cfgs, nil
+}
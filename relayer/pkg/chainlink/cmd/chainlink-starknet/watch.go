@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils"
+
+	starkchain "github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/chain"
+)
+
+const (
+	// configFileEnv names a file with [Starknet] or [[Starknet]] tables that is applied to the running chains whenever
+	// it changes, see configWatcher
+	configFileEnv = "CL_STARKNET_CONFIG_FILE"
+
+	configWatchPeriod = 10 * time.Second
+)
+
+// configWatcher updates the chains from a config file without restarting the plugin. The file holds the full config
+// of the chains to update, in the same format as the node config, and is applied with [starkchain.Chain.UpdateConfig]
+// whenever its modification time changes. Chains can't be added or removed this way.
+type configWatcher struct {
+	utils.StartStopOnce
+
+	path   string
+	period time.Duration
+	chains map[string]starkchain.Chain
+	lggr   logger.Logger
+
+	lock    sync.Mutex
+	modTime time.Time
+	lastErr error
+
+	stop, done chan struct{}
+}
+
+func newConfigWatcher(path string, period time.Duration, chains []starkchain.Chain, lggr logger.Logger) *configWatcher {
+	w := &configWatcher{
+		path:   path,
+		period: period,
+		chains: make(map[string]starkchain.Chain, len(chains)),
+		lggr:   logger.Named(lggr, "ConfigWatcher"),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	for _, chain := range chains {
+		w.chains[chain.ID()] = chain
+	}
+	return w
+}
+
+func (w *configWatcher) Name() string {
+	return w.lggr.Name()
+}
+
+func (w *configWatcher) Start(context.Context) error {
+	return w.StartOnce("ConfigWatcher", func() error {
+		// the chains were created from the node config, the file only applies once it changes
+		if info, err := os.Stat(w.path); err == nil {
+			w.modTime = info.ModTime()
+		}
+		go w.run()
+		return nil
+	})
+}
+
+func (w *configWatcher) Close() error {
+	return w.StopOnce("ConfigWatcher", func() error {
+		close(w.stop)
+		<-w.done
+		return nil
+	})
+}
+
+// HealthReport reports the last file that couldn't be applied, until a change of the file is applied
+func (w *configWatcher) HealthReport() map[string]error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return map[string]error{w.Name(): errors.Join(w.Healthy(), w.lastErr)}
+}
+
+func (w *configWatcher) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.check()
+		}
+	}
+}
+
+// check applies the file if it changed since the last check
+func (w *configWatcher) check() {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		w.lggr.Warnw("couldn't read config file", "path", w.path, "err", err)
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if info.ModTime().Equal(w.modTime) {
+		return
+	}
+	// a broken file is reported once and not retried until it is changed again
+	w.modTime = info.ModTime()
+	w.lastErr = w.apply()
+	if w.lastErr != nil {
+		w.lggr.Errorw("couldn't apply config file", "path", w.path, "err", w.lastErr)
+		return
+	}
+	w.lggr.Infow("applied config file", "path", w.path)
+}
+
+func (w *configWatcher) apply() error {
+	b, err := os.ReadFile(w.path)
+	if err != nil {
+		return fmt.Errorf("couldn't read config file: %w", err)
+	}
+	cfgs, err := decodeConfigs(string(b))
+	if err != nil {
+		return err
+	}
+	for _, cfg := range cfgs {
+		if _, ok := w.chains[*cfg.ChainID]; !ok {
+			return fmt.Errorf("chain %s is not running, adding chains requires a restart", *cfg.ChainID)
+		}
+	}
+	// chains are updated on their own, an invalid config of one chain doesn't hold back the others
+	for _, cfg := range cfgs {
+		if updateErr := w.chains[*cfg.ChainID].UpdateConfig(cfg); updateErr != nil {
+			err = errors.Join(err, fmt.Errorf("couldn't update chain %s: %w", *cfg.ChainID, updateErr))
+		}
+	}
+	return err
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	starkchain "github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/chain"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/keystore"
+)
+
+func TestConfigWatcher(t *testing.T) {
+	config := func(node string) string {
+		return `
+[[Starknet]]
+ChainID = 'SN_SEPOLIA'
+
+[[Starknet.Nodes]]
+Name = '` + node + `'
+URL = 'https://` + node + `.example'
+`
+	}
+	cfgs, err := decodeConfigs(config("a"))
+	require.NoError(t, err)
+	chain, err := starkchain.NewChain(cfgs[0], starkchain.ChainOpts{Logger: logger.Test(t), KeyStore: keystore.NewMemoryKeystore()})
+	require.NoError(t, err)
+	nodeNames := func() (names []string) {
+		nodes, _, _, err := chain.ListNodeStatuses(tests.Context(t), 10, "")
+		require.NoError(t, err)
+		for _, n := range nodes {
+			names = append(names, n.Name)
+		}
+		return names
+	}
+
+	path := filepath.Join(t.TempDir(), "starknet.toml")
+	modTime := time.Now()
+	write := func(config string) {
+		require.NoError(t, os.WriteFile(path, []byte(config), 0600))
+		modTime = modTime.Add(time.Second)
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	write(config("b"))
+
+	w := newConfigWatcher(path, time.Hour, []starkchain.Chain{chain}, logger.Test(t))
+	require.NoError(t, w.Start(tests.Context(t)))
+	t.Cleanup(func() { require.NoError(t, w.Close()) })
+
+	// the file present at start is not applied on top of the node config
+	w.check()
+	assert.Equal(t, []string{"a"}, nodeNames())
+
+	write(config("c"))
+	w.check()
+	assert.Equal(t, []string{"c"}, nodeNames())
+	assert.NoError(t, w.HealthReport()[w.Name()])
+
+	// invalid files are reported and leave the chains as they are
+	write(`[[Starknet]]
+ChainID = 'SN_MAIN'`)
+	w.check()
+	assert.ErrorContains(t, w.HealthReport()[w.Name()], "invalid starknet chain")
+	write(config("d") + "\n[[Starknet]]\nChainID = 'SN_MAIN'\n[[Starknet.Nodes]]\nName = 'm'\nURL = 'https://m.example'\n")
+	w.check()
+	assert.ErrorContains(t, w.HealthReport()[w.Name()], "chain SN_MAIN is not running")
+	assert.Equal(t, []string{"c"}, nodeNames())
+
+	write(config("d"))
+	w.check()
+	assert.NoError(t, w.HealthReport()[w.Name()])
+	assert.Equal(t, []string{"d"}, nodeNames())
+}
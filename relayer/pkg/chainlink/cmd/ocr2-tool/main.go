@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2/medianreport"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+)
+
+// ocr2-tool is a debugging CLI for the OCR2 aggregator, e.g.
+//
+//	ocr2-tool report -report 0x...
+//	ocr2-tool report -rpc https://... -contract 0x... -tx 0x...
+//	ocr2-tool verify -rpc https://... -contract 0x... -tx 0x...
+//	ocr2-tool owed -rpc https://... -contracts 0x...,0x...
+//	STARKNET_PRIVATE_KEY=0x... ocr2-tool withdraw -rpc https://... -account 0x... -contracts 0x...,0x... -transmitter 0x...
+//	STARKNET_PRIVATE_KEY=0x... STARKNET_NEW_PRIVATE_KEY=0x... ocr2-tool rotate-key -rpc https://... -account 0x...
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: ocr2-tool <report|verify|owed|withdraw|set-payees|transfer-payeeship|accept-payeeship|rotate-key> [flags]")
+		os.Exit(2)
+	}
+
+	var err error
+	switch strings.ToLower(os.Args[1]) {
+	case "report":
+		err = runReport(os.Args[2:], os.Stdout)
+	case "verify":
+		err = runVerify(os.Args[2:], os.Stdout)
+	case "owed":
+		err = runOwed(os.Args[2:], os.Stdout)
+	case "withdraw":
+		err = runWithdraw(os.Args[2:], os.Stdout)
+	case "set-payees":
+		err = runSetPayees(os.Args[2:], os.Stdout)
+	case "transfer-payeeship":
+		err = runTransferPayeeship(os.Args[2:], os.Stdout)
+	case "accept-payeeship":
+		err = runAcceptPayeeship(os.Args[2:], os.Stdout)
+	case "rotate-key":
+		err = runRotateKey(os.Args[2:], os.Stdout)
+	default:
+		err = fmt.Errorf("unknown command: %s", os.Args[1])
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// runReport decodes a hex encoded report or the transmit call of a transaction
+func runReport(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	reportHex := fs.String("report", "", "hex encoded report")
+	rpcURL := fs.String("rpc", "", "starknet RPC URL, used with -tx")
+	txHash := fs.String("tx", "", "hash of a transaction calling transmit")
+	contract := fs.String("contract", "", "aggregator address, used with -tx")
+	signed := fs.Bool("signed", false, "decode observations as i128, for aggregators with signed answers")
+	timeout := fs.Duration("timeout", 10*time.Second, "RPC request timeout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	switch {
+	case *reportHex != "":
+		report, err := hex.DecodeString(strings.TrimPrefix(*reportHex, "0x"))
+		if err != nil {
+			return fmt.Errorf("couldn't decode report hex: %w", err)
+		}
+		return printReport(w, report, *signed)
+	case *txHash != "":
+		if *rpcURL == "" || *contract == "" {
+			return errors.New("-tx requires -rpc and -contract")
+		}
+		hash, err := starknetutils.HexToFelt(*txHash)
+		if err != nil {
+			return fmt.Errorf("invalid transaction hash: %w", err)
+		}
+		contractAddress, err := starknetutils.HexToFelt(*contract)
+		if err != nil {
+			return fmt.Errorf("invalid contract address: %w", err)
+		}
+
+		client, err := starknet.NewClient("", *rpcURL, "", logger.Nop(), timeout)
+		if err != nil {
+			return fmt.Errorf("couldn't create client: %w", err)
+		}
+		tx, err := client.TransactionByHash(context.Background(), hash)
+		if err != nil {
+			return fmt.Errorf("couldn't fetch transaction: %w", err)
+		}
+		transmit, err := ocr2.ParseTransmitTransaction(tx, contractAddress)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "config digest:  %s\n", transmit.ConfigDigest.Hex())
+		fmt.Fprintf(w, "epoch:          %d\n", transmit.Epoch)
+		fmt.Fprintf(w, "round:          %d\n", transmit.Round)
+		fmt.Fprintf(w, "extra hash:     %s\n", transmit.ExtraHash)
+		for i, sig := range transmit.Signatures {
+			fmt.Fprintf(w, "signature %d:    public_key=%s r=%s s=%s\n", i, sig.PublicKey, sig.R, sig.S)
+		}
+		if err = printReport(w, transmit.Report, *signed); err != nil {
+			return err
+		}
+		return printPayment(w, client, contractAddress, hash, transmit, *signed)
+	default:
+		return errors.New("either -report or -tx is required")
+	}
+}
+
+func printReport(w io.Writer, report []byte, signed bool) error {
+	r, err := medianreport.ReportCodec{Signed: signed}.ParseReport(report)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "timestamp:      %d (%s)\n", r.ObservationTimestamp, time.Unix(int64(r.ObservationTimestamp), 0).UTC())
+	fmt.Fprintf(w, "observations:   %d\n", len(r.Observations))
+	for i, o := range r.Observations {
+		fmt.Fprintf(w, "  observer %2d:  %s\n", r.Observers[i], o)
+	}
+	median, err := r.Median()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "median:         %s\n", median)
+	fmt.Fprintf(w, "juels per fee:  %s\n", r.JuelsPerFeeCoin)
+	fmt.Fprintf(w, "gas price:      %s\n", r.GasPrice)
+	return nil
+}
+
+// printPayment compares the payment predicted from the current billing config with the fee paid by the transaction
+func printPayment(w io.Writer, client *starknet.Client, contractAddress, hash *felt.Felt, transmit ocr2.TransmitArgs, signed bool) error {
+	ctx := context.Background()
+	r, err := medianreport.ReportCodec{Signed: signed}.ParseReport(transmit.Report)
+	if err != nil {
+		return err
+	}
+	reader, err := ocr2.NewClient(client, logger.Nop())
+	if err != nil {
+		return fmt.Errorf("couldn't create ocr2 client: %w", err)
+	}
+	billing, err := reader.BillingDetails(ctx, contractAddress)
+	if err != nil {
+		return fmt.Errorf("couldn't fetch billing: %w", err)
+	}
+	reimbursement, payment, err := ocr2.PredictTransmissionPayment(r, len(transmit.Signatures), billing)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "reimbursement:  %s juels\n", reimbursement)
+	fmt.Fprintf(w, "payment:        %s juels\n", payment)
+
+	receipt, err := client.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("couldn't fetch receipt: %w", err)
+	}
+	fee, err := starknet.InvokeActualFee(receipt)
+	if err != nil {
+		return err
+	}
+	if fee.Amount != nil {
+		fmt.Fprintf(w, "actual fee:     %s %s (%s juels)\n", fee.Amount.BigInt(new(big.Int)), fee.Unit, ocr2.FeeInJuels(fee.Amount, r.JuelsPerFeeCoin))
+	}
+	return nil
+}
+
+// runVerify checks the signatures of a historical transmit against the config it was signed for
+func runVerify(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	rpcURL := fs.String("rpc", "", "starknet RPC URL")
+	txHash := fs.String("tx", "", "hash of a transaction calling transmit")
+	contract := fs.String("contract", "", "aggregator address")
+	chainID := fs.String("chain-id", "", "chain ID used in config digests (e.g. SN_SEPOLIA), defaults to the RPC's chain ID")
+	timeout := fs.Duration("timeout", 10*time.Second, "RPC request timeout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *rpcURL == "" || *txHash == "" || *contract == "" {
+		return errors.New("-rpc, -tx and -contract are required")
+	}
+	hash, err := starknetutils.HexToFelt(*txHash)
+	if err != nil {
+		return fmt.Errorf("invalid transaction hash: %w", err)
+	}
+	contractAddress, err := starknetutils.HexToFelt(*contract)
+	if err != nil {
+		return fmt.Errorf("invalid contract address: %w", err)
+	}
+
+	ctx := context.Background()
+	client, err := starknet.NewClient("", *rpcURL, "", logger.Nop(), timeout)
+	if err != nil {
+		return fmt.Errorf("couldn't create client: %w", err)
+	}
+	if *chainID == "" {
+		id, err := client.ChainID(ctx)
+		if err != nil {
+			return fmt.Errorf("couldn't fetch chain ID: %w", err)
+		}
+		decoded, err := hex.DecodeString(strings.TrimPrefix(id, "0x"))
+		if err != nil {
+			return fmt.Errorf("couldn't decode chain ID %s: %w", id, err)
+		}
+		*chainID = string(decoded)
+	}
+
+	tx, err := client.TransactionByHash(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("couldn't fetch transaction: %w", err)
+	}
+	transmit, err := ocr2.ParseTransmitTransaction(tx, contractAddress)
+	if err != nil {
+		return err
+	}
+
+	reader, err := ocr2.NewClient(client, logger.Nop())
+	if err != nil {
+		return err
+	}
+	history, err := reader.ConfigHistory(ctx, *chainID, contractAddress)
+	if err != nil {
+		return fmt.Errorf("couldn't fetch config history: %w", err)
+	}
+	for _, entry := range history {
+		if entry.Config.ConfigDigest != transmit.ConfigDigest {
+			continue
+		}
+		fmt.Fprintf(w, "config digest:  %s (set in block %d)\n", transmit.ConfigDigest.Hex(), entry.ConfigBlock)
+		if err := ocr2.VerifyReportSignatures(entry.Config, transmit.ReportContext(), transmit.Report, transmit.Signatures); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "signatures:     %d valid\n", len(transmit.Signatures))
+		return nil
+	}
+	return fmt.Errorf("config digest %s not found in the contract's config history", transmit.ConfigDigest.Hex())
+}
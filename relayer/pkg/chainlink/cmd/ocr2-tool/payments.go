@@ -0,0 +1,420 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/keystore"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/txm"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+)
+
+// privateKeyEnv holds the key of the account sending the payment txs, it isn't a flag to keep it out of shell history
+const privateKeyEnv = "STARKNET_PRIVATE_KEY"
+
+// runOwed prints the LINK owed to each transmitter of the given feeds
+func runOwed(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("owed", flag.ContinueOnError)
+	rpcURL := fs.String("rpc", "", "starknet RPC URL")
+	contracts := fs.String("contracts", "", "comma separated aggregator addresses")
+	transmitters := fs.String("transmitters", "", "comma separated transmitters, defaults to the transmitters of each feed's latest config")
+	timeout := fs.Duration("timeout", 10*time.Second, "RPC request timeout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *rpcURL == "" || *contracts == "" {
+		return errors.New("-rpc and -contracts are required")
+	}
+	contractAddresses, err := parseFelts(*contracts)
+	if err != nil {
+		return fmt.Errorf("invalid contract address: %w", err)
+	}
+	var transmitterAddresses []*felt.Felt
+	if *transmitters != "" {
+		if transmitterAddresses, err = parseFelts(*transmitters); err != nil {
+			return fmt.Errorf("invalid transmitter: %w", err)
+		}
+	}
+
+	ctx := context.Background()
+	client, err := starknet.NewClient("", *rpcURL, "", logger.Nop(), timeout)
+	if err != nil {
+		return fmt.Errorf("couldn't create client: %w", err)
+	}
+	reader, err := ocr2.NewClient(client, logger.Nop())
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CONTRACT\tTRANSMITTER\tOWED (JUELS)")
+	totals := map[string]*big.Int{}
+	var order []string
+	for _, contract := range contractAddresses {
+		feedTransmitters := transmitterAddresses
+		if feedTransmitters == nil {
+			if feedTransmitters, err = latestTransmitters(ctx, reader, contract); err != nil {
+				return err
+			}
+		}
+		for _, transmitter := range feedTransmitters {
+			owed, err := reader.OwedPayment(ctx, contract, transmitter)
+			if err != nil {
+				return fmt.Errorf("couldn't fetch payment owed to %s by %s: %w", transmitter, contract, err)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", contract, transmitter, owed)
+
+			key := transmitter.String()
+			if _, ok := totals[key]; !ok {
+				totals[key] = big.NewInt(0)
+				order = append(order, key)
+			}
+			totals[key].Add(totals[key], owed)
+		}
+	}
+	for _, transmitter := range order {
+		fmt.Fprintf(tw, "total\t%s\t%s\n", transmitter, totals[transmitter])
+	}
+	return tw.Flush()
+}
+
+func latestTransmitters(ctx context.Context, reader *ocr2.Client, contract *felt.Felt) ([]*felt.Felt, error) {
+	details, err := reader.LatestConfigDetails(ctx, contract)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch latest config details of %s: %w", contract, err)
+	}
+	config, err := reader.ConfigFromEventAt(ctx, contract, details.Block)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch latest config of %s: %w", contract, err)
+	}
+	var transmitters []*felt.Felt
+	for _, t := range config.Config.Transmitters {
+		transmitter, err := starknetutils.HexToFelt(string(t))
+		if err != nil {
+			return nil, fmt.Errorf("invalid transmitter %s in config of %s: %w", t, contract, err)
+		}
+		transmitters = append(transmitters, transmitter)
+	}
+	return transmitters, nil
+}
+
+// runWithdraw withdraws the payments owed to a transmitter by the given feeds, it has to be run by the payee
+func runWithdraw(args []string, w io.Writer) error {
+	opts, cmd, err := parseWithdraw(args)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, err := opts.client()
+	if err != nil {
+		return err
+	}
+	reader, err := ocr2.NewClient(client, logger.Nop())
+	if err != nil {
+		return err
+	}
+
+	var calls []starknetrpc.FunctionCall
+	for _, contract := range cmd.contracts {
+		owed, err := reader.OwedPayment(ctx, contract, cmd.transmitter)
+		if err != nil {
+			return fmt.Errorf("couldn't fetch payment owed by %s: %w", contract, err)
+		}
+		if owed.Cmp(cmd.minOwed) < 0 {
+			fmt.Fprintf(w, "skipping %s: owes %s juels\n", contract, owed)
+			continue
+		}
+		fmt.Fprintf(w, "withdrawing %s juels from %s\n", owed, contract)
+		calls = append(calls, ocr2.WithdrawPaymentCall(contract, cmd.transmitter))
+	}
+	return opts.submit(ctx, client, calls, w)
+}
+
+// withdrawCommand are the arguments of withdraw, which calls are sent depends on the payments owed
+type withdrawCommand struct {
+	contracts   []*felt.Felt
+	transmitter *felt.Felt
+	minOwed     *big.Int
+}
+
+func parseWithdraw(args []string) (txOptions, withdrawCommand, error) {
+	fs := flag.NewFlagSet("withdraw", flag.ContinueOnError)
+	opts := txFlags(fs)
+	contracts := fs.String("contracts", "", "comma separated aggregator addresses")
+	transmitter := fs.String("transmitter", "", "transmitter to withdraw the payments of")
+	minOwed := fs.String("min-owed", "1", "skip feeds owing less juels")
+	if err := fs.Parse(args); err != nil {
+		return opts, withdrawCommand{}, err
+	}
+	if *contracts == "" || *transmitter == "" {
+		return opts, withdrawCommand{}, errors.New("-contracts and -transmitter are required")
+	}
+	contractAddresses, err := parseFelts(*contracts)
+	if err != nil {
+		return opts, withdrawCommand{}, fmt.Errorf("invalid contract address: %w", err)
+	}
+	transmitterAddress, err := starknetutils.HexToFelt(*transmitter)
+	if err != nil {
+		return opts, withdrawCommand{}, fmt.Errorf("invalid transmitter: %w", err)
+	}
+	threshold, ok := new(big.Int).SetString(*minOwed, 10)
+	if !ok {
+		return opts, withdrawCommand{}, fmt.Errorf("invalid -min-owed: %s", *minOwed)
+	}
+	return opts, withdrawCommand{contracts: contractAddresses, transmitter: transmitterAddress, minOwed: threshold}, nil
+}
+
+// runSetPayees sets the initial payees of a feed, it has to be run by the aggregator owner
+func runSetPayees(args []string, w io.Writer) error {
+	return runCalls(args, w, setPayeesCalls)
+}
+
+func setPayeesCalls(args []string) (txOptions, []starknetrpc.FunctionCall, error) {
+	fs := flag.NewFlagSet("set-payees", flag.ContinueOnError)
+	opts := txFlags(fs)
+	contract := fs.String("contract", "", "aggregator address")
+	payees := fs.String("payees", "", "comma separated transmitter=payee pairs")
+	if err := fs.Parse(args); err != nil {
+		return opts, nil, err
+	}
+	if *contract == "" || *payees == "" {
+		return opts, nil, errors.New("-contract and -payees are required")
+	}
+	contractAddress, err := starknetutils.HexToFelt(*contract)
+	if err != nil {
+		return opts, nil, fmt.Errorf("invalid contract address: %w", err)
+	}
+	var configs []ocr2.PayeeConfig
+	for _, pair := range strings.Split(*payees, ",") {
+		transmitter, payee, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return opts, nil, fmt.Errorf("invalid payee %q: expected transmitter=payee", pair)
+		}
+		addresses, err := parseFelts(transmitter + "," + payee)
+		if err != nil {
+			return opts, nil, fmt.Errorf("invalid payee %q: %w", pair, err)
+		}
+		configs = append(configs, ocr2.PayeeConfig{Transmitter: addresses[0], Payee: addresses[1]})
+	}
+	return opts, []starknetrpc.FunctionCall{ocr2.SetPayeesCall(contractAddress, configs)}, nil
+}
+
+// runTransferPayeeship proposes a new payee, it has to be run by the current payee
+func runTransferPayeeship(args []string, w io.Writer) error {
+	return runCalls(args, w, transferPayeeshipCalls)
+}
+
+func transferPayeeshipCalls(args []string) (txOptions, []starknetrpc.FunctionCall, error) {
+	fs := flag.NewFlagSet("transfer-payeeship", flag.ContinueOnError)
+	opts := txFlags(fs)
+	contract := fs.String("contract", "", "aggregator address")
+	transmitter := fs.String("transmitter", "", "transmitter to change the payee of")
+	proposed := fs.String("proposed", "", "proposed payee")
+	if err := fs.Parse(args); err != nil {
+		return opts, nil, err
+	}
+	if *contract == "" || *transmitter == "" || *proposed == "" {
+		return opts, nil, errors.New("-contract, -transmitter and -proposed are required")
+	}
+	addresses, err := parseFelts(*contract + "," + *transmitter + "," + *proposed)
+	if err != nil {
+		return opts, nil, err
+	}
+	return opts, []starknetrpc.FunctionCall{ocr2.TransferPayeeshipCall(addresses[0], addresses[1], addresses[2])}, nil
+}
+
+// runAcceptPayeeship accepts a proposed payeeship, it has to be run by the proposed payee
+func runAcceptPayeeship(args []string, w io.Writer) error {
+	return runCalls(args, w, acceptPayeeshipCalls)
+}
+
+func acceptPayeeshipCalls(args []string) (txOptions, []starknetrpc.FunctionCall, error) {
+	fs := flag.NewFlagSet("accept-payeeship", flag.ContinueOnError)
+	opts := txFlags(fs)
+	contract := fs.String("contract", "", "aggregator address")
+	transmitter := fs.String("transmitter", "", "transmitter to accept the payeeship of")
+	if err := fs.Parse(args); err != nil {
+		return opts, nil, err
+	}
+	if *contract == "" || *transmitter == "" {
+		return opts, nil, errors.New("-contract and -transmitter are required")
+	}
+	addresses, err := parseFelts(*contract + "," + *transmitter)
+	if err != nil {
+		return opts, nil, err
+	}
+	return opts, []starknetrpc.FunctionCall{ocr2.AcceptPayeeshipCall(addresses[0], addresses[1])}, nil
+}
+
+// runCalls submits the calls built from the flags of a command
+func runCalls(args []string, w io.Writer, build func([]string) (txOptions, []starknetrpc.FunctionCall, error)) error {
+	opts, calls, err := build(args)
+	if err != nil {
+		return err
+	}
+	client, err := opts.client()
+	if err != nil {
+		return err
+	}
+	return opts.submit(context.Background(), client, calls, w)
+}
+
+// txOptions are the flags shared by the commands sending txs
+type txOptions struct {
+	rpcURL    *string
+	feederURL *string
+	account   *string
+	timeout   *time.Duration
+	wait      *time.Duration
+}
+
+func txFlags(fs *flag.FlagSet) txOptions {
+	return txOptions{
+		rpcURL:    fs.String("rpc", "", "starknet RPC URL"),
+		feederURL: fs.String("feeder", "", "starknet feeder URL, used to look up the reason of rejected txs"),
+		account:   fs.String("account", "", "address of the account sending the txs, its key is read from $"+privateKeyEnv),
+		timeout:   fs.Duration("timeout", 10*time.Second, "RPC request timeout"),
+		wait:      fs.Duration("wait", 5*time.Minute, "how long to wait for the txs to be confirmed"),
+	}
+}
+
+func (o txOptions) client() (*starknet.Client, error) {
+	if *o.rpcURL == "" {
+		return nil, errors.New("-rpc is required")
+	}
+	client, err := starknet.NewClient("", *o.rpcURL, "", logger.Nop(), o.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create client: %w", err)
+	}
+	return client, nil
+}
+
+// submit sends the calls through the txm and waits until each of them is confirmed, it fails if any tx failed to
+// broadcast, was rejected or reverted. The txm logs the reason of the rejected ones.
+func (o txOptions) submit(ctx context.Context, client *starknet.Client, calls []starknetrpc.FunctionCall, w io.Writer) error {
+	if len(calls) == 0 {
+		fmt.Fprintln(w, "nothing to submit")
+		return nil
+	}
+	if *o.account == "" {
+		return errors.New("-account is required")
+	}
+	accountAddress, err := starknetutils.HexToFelt(*o.account)
+	if err != nil {
+		return fmt.Errorf("invalid account address: %w", err)
+	}
+	ks := keystore.NewMemoryKeystore()
+	publicKey, err := addPrivateKey(ks, privateKeyEnv)
+	if err != nil {
+		return err
+	}
+	manager, err := o.startTxm(ctx, ks, client)
+	if err != nil {
+		return err
+	}
+	defer manager.Close()
+
+	results := make([]<-chan txm.TxResult, len(calls))
+	for i, call := range calls {
+		if results[i], err = manager.EnqueueWithResult(ctx, accountAddress, publicKey, call); err != nil {
+			return err
+		}
+	}
+	return waitForResults(calls, results, *o.wait, w)
+}
+
+// waitForResults prints the outcome of each call, it fails if any of them failed or wasn't confirmed in time
+func waitForResults(calls []starknetrpc.FunctionCall, results []<-chan txm.TxResult, wait time.Duration, w io.Writer) error {
+	deadline := time.After(wait)
+	failed := 0
+	for i, result := range results {
+		select {
+		case <-deadline:
+			return fmt.Errorf("timed out waiting for txs: %d of %d not confirmed", len(results)-i, len(results))
+		case res := <-result:
+			if res.Err != nil {
+				failed++
+				fmt.Fprintf(w, "call to %s failed: %v\n", calls[i].ContractAddress, res.Err)
+				continue
+			}
+			fmt.Fprintf(w, "call to %s confirmed in tx %s\n", calls[i].ContractAddress, res.Hash)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d txs failed", failed, len(calls))
+	}
+	fmt.Fprintf(w, "%d txs confirmed\n", len(calls))
+	return nil
+}
+
+type txmConfig struct {
+	txTimeout time.Duration
+}
+
+func (c txmConfig) ConfirmationPoll() time.Duration { return 5 * time.Second }
+func (c txmConfig) TxTimeout() time.Duration        { return c.txTimeout }
+func (c txmConfig) AccountClassHash() string        { return "" }
+
+// startTxm starts a txm sending through the client and signing with the keys of the keystore
+func (o txOptions) startTxm(ctx context.Context, ks *keystore.MemoryKeystore, client *starknet.Client) (txm.StarkTXM, error) {
+	lggr, err := logger.New()
+	if err != nil {
+		return nil, err
+	}
+	manager, err := txm.New(lggr, ks, txmConfig{txTimeout: *o.timeout}, func() (*starknet.Client, error) {
+		return client, nil
+	}, func() (*starknet.FeederClient, error) {
+		return starknet.NewFeederClient(*o.feederURL), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = manager.Start(ctx); err != nil {
+		return nil, err
+	}
+	return manager, nil
+}
+
+// addPrivateKey loads the private key held by the env var into the keystore, returning its public key
+func addPrivateKey(ks *keystore.MemoryKeystore, env string) (*felt.Felt, error) {
+	privateKeyHex := os.Getenv(env)
+	if privateKeyHex == "" {
+		return nil, fmt.Errorf("$%s is required to send txs", env)
+	}
+	privateKey, ok := new(big.Int).SetString(strings.TrimPrefix(privateKeyHex, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid $%s", env)
+	}
+	publicKey, err := ks.Add(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid $%s: %w", env, err)
+	}
+	return publicKey, nil
+}
+
+func parseFelts(list string) (felts []*felt.Felt, err error) {
+	for _, s := range strings.Split(list, ",") {
+		f, err := starknetutils.HexToFelt(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", s, err)
+		}
+		felts = append(felts, f)
+	}
+	return felts, nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/txm"
+)
+
+func felts(values ...uint64) (out []*felt.Felt) {
+	for _, v := range values {
+		out = append(out, new(felt.Felt).SetUint64(v))
+	}
+	return out
+}
+
+func TestParseWithdraw(t *testing.T) {
+	opts, cmd, err := parseWithdraw([]string{"-rpc", "http://node", "-account", "0xacc", "-contracts", "0x1, 0x2", "-transmitter", "0x3", "-wait", "1m"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://node", *opts.rpcURL)
+	assert.Equal(t, "0xacc", *opts.account)
+	assert.Equal(t, time.Minute, *opts.wait)
+	assert.Equal(t, felts(1, 2), cmd.contracts)
+	assert.Equal(t, felts(3)[0], cmd.transmitter)
+	assert.Equal(t, big.NewInt(1), cmd.minOwed)
+
+	_, cmd, err = parseWithdraw([]string{"-contracts", "0x1", "-transmitter", "0x3", "-min-owed", "1000"})
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000), cmd.minOwed)
+
+	for expected, args := range map[string][]string{
+		"-contracts and -transmitter are required": {"-contracts", "0x1"},
+		"invalid contract address":                 {"-contracts", "0x1,zz", "-transmitter", "0x3"},
+		"invalid transmitter":                      {"-contracts", "0x1", "-transmitter", "zz"},
+		"invalid -min-owed":                        {"-contracts", "0x1", "-transmitter", "0x3", "-min-owed", "0x10"},
+	} {
+		_, _, err := parseWithdraw(args)
+		assert.ErrorContains(t, err, expected)
+	}
+}
+
+func TestPaymentCommandCalls(t *testing.T) {
+	aggregator := felts(0xa)[0]
+
+	_, calls, err := setPayeesCalls([]string{"-contract", "0xa", "-payees", "0x1=0x11, 0x2=0x12"})
+	require.NoError(t, err)
+	assert.Equal(t, []starknetrpc.FunctionCall{ocr2.SetPayeesCall(aggregator, []ocr2.PayeeConfig{
+		{Transmitter: felts(1)[0], Payee: felts(0x11)[0]},
+		{Transmitter: felts(2)[0], Payee: felts(0x12)[0]},
+	})}, calls)
+	_, _, err = setPayeesCalls([]string{"-contract", "0xa", "-payees", "0x1:0x11"})
+	assert.ErrorContains(t, err, "expected transmitter=payee")
+	_, _, err = setPayeesCalls([]string{"-contract", "0xa"})
+	assert.ErrorContains(t, err, "-contract and -payees are required")
+
+	_, calls, err = transferPayeeshipCalls([]string{"-contract", "0xa", "-transmitter", "0x1", "-proposed", "0x13"})
+	require.NoError(t, err)
+	assert.Equal(t, []starknetrpc.FunctionCall{ocr2.TransferPayeeshipCall(aggregator, felts(1)[0], felts(0x13)[0])}, calls)
+	_, _, err = transferPayeeshipCalls([]string{"-contract", "0xa", "-transmitter", "0x1"})
+	assert.ErrorContains(t, err, "-contract, -transmitter and -proposed are required")
+
+	_, calls, err = acceptPayeeshipCalls([]string{"-contract", "0xa", "-transmitter", "0x1"})
+	require.NoError(t, err)
+	assert.Equal(t, []starknetrpc.FunctionCall{ocr2.AcceptPayeeshipCall(aggregator, felts(1)[0])}, calls)
+	_, _, err = acceptPayeeshipCalls([]string{"-contract", "0xa", "-transmitter", "zz"})
+	assert.Error(t, err)
+
+	_, _, err = acceptPayeeshipCalls([]string{"-unknown"})
+	assert.Error(t, err)
+}
+
+func TestWaitForResults(t *testing.T) {
+	calls := []starknetrpc.FunctionCall{{ContractAddress: felts(1)[0]}, {ContractAddress: felts(2)[0]}}
+	results := func(res ...txm.TxResult) []<-chan txm.TxResult {
+		var out []<-chan txm.TxResult
+		for _, r := range res {
+			c := make(chan txm.TxResult, 1)
+			c <- r
+			out = append(out, c)
+		}
+		return out
+	}
+
+	var w bytes.Buffer
+	require.NoError(t, waitForResults(calls, results(txm.TxResult{Hash: "0x11"}, txm.TxResult{Hash: "0x12"}), time.Second, &w))
+	assert.Contains(t, w.String(), "2 txs confirmed")
+
+	w.Reset()
+	err := waitForResults(calls, results(txm.TxResult{Hash: "0x11"}, txm.TxResult{Hash: "0x12", Err: errors.New("transaction 0x12 reverted")}), time.Second, &w)
+	require.ErrorContains(t, err, "1 of 2 txs failed")
+	assert.Contains(t, w.String(), "call to 0x2 failed: transaction 0x12 reverted")
+
+	pending := append(results(txm.TxResult{Hash: "0x11"}), make(chan txm.TxResult))
+	err = waitForResults(calls, pending, 10*time.Millisecond, &w)
+	require.ErrorContains(t, err, "1 of 2 not confirmed")
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/keystore"
+)
+
+// newPrivateKeyEnv holds the key the account is handed over to by rotate-key
+const newPrivateKeyEnv = "STARKNET_NEW_PRIVATE_KEY"
+
+// runRotateKey hands an OpenZeppelin account over to a new key: set_public_key is sent with the current key and the
+// new key is verified on-chain. It runs its own TXM, so no node may send txs of the account meanwhile; accounts of a
+// running node are rotated through its relayer instead, which drains them first. Nodes holding the new key sign with
+// it once they see it on-chain, the public key in the relay config of the jobs should still be updated.
+func runRotateKey(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("rotate-key", flag.ContinueOnError)
+	opts := txFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *opts.account == "" {
+		return errors.New("-account is required")
+	}
+	accountAddress, err := starknetutils.HexToFelt(*opts.account)
+	if err != nil {
+		return fmt.Errorf("invalid account address: %w", err)
+	}
+	ks := keystore.NewMemoryKeystore()
+	currentKey, err := addPrivateKey(ks, privateKeyEnv)
+	if err != nil {
+		return err
+	}
+	newKey, err := addPrivateKey(ks, newPrivateKeyEnv)
+	if err != nil {
+		return err
+	}
+	if currentKey.Equal(newKey) {
+		return fmt.Errorf("$%s and $%s hold the same key", privateKeyEnv, newPrivateKeyEnv)
+	}
+	client, err := opts.client()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *opts.wait)
+	defer cancel()
+	manager, err := opts.startTxm(ctx, ks, client)
+	if err != nil {
+		return err
+	}
+	defer manager.Close()
+	if err = manager.RotateKey(ctx, accountAddress, currentKey, newKey); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "account %s is controlled by key %s\n", accountAddress, newKey)
+	fmt.Fprintln(w, "update the public key of the account in the relay config of the jobs using it")
+	return nil
+}
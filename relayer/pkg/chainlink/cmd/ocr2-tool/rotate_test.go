@@ -0,0 +1,23 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunRotateKey_Args(t *testing.T) {
+	args := []string{"-rpc", "http://node", "-account", "0xacc"}
+	assert.ErrorContains(t, runRotateKey(args[:2], io.Discard), "-account is required")
+
+	t.Setenv(privateKeyEnv, "0x1234")
+	t.Setenv(newPrivateKeyEnv, "")
+	assert.ErrorContains(t, runRotateKey(args, io.Discard), "$"+newPrivateKeyEnv+" is required")
+
+	t.Setenv(newPrivateKeyEnv, "0x1234")
+	assert.ErrorContains(t, runRotateKey(args, io.Discard), "hold the same key")
+
+	t.Setenv(newPrivateKeyEnv, "zz")
+	assert.ErrorContains(t, runRotateKey(args, io.Discard), "invalid $"+newPrivateKeyEnv)
+}
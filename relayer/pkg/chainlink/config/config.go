@@ -7,19 +7,25 @@ import (
 	"slices"
 	"time"
 
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
 	"github.com/pelletier/go-toml/v2"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/config"
 
 	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/db"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/keystore"
 	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2"
 	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/txm"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
 )
 
 var DefaultConfigSet = ConfigSet{
 	OCR2CachePollPeriod: 5 * time.Second,
 	OCR2CacheTTL:        time.Minute,
 	RequestTimeout:      10 * time.Second,
+	RequestMaxRetries:   3,
+	RequestRetryMinWait: 500 * time.Millisecond,
+	RequestRetryMaxWait: 5 * time.Second,
 	TxTimeout:           10 * time.Second,
 	ConfirmationPoll:    5 * time.Second,
 }
@@ -29,7 +35,10 @@ type ConfigSet struct { //nolint:revive
 	OCR2CacheTTL        time.Duration
 
 	// client config
-	RequestTimeout time.Duration
+	RequestTimeout      time.Duration
+	RequestMaxRetries   uint32
+	RequestRetryMinWait time.Duration
+	RequestRetryMaxWait time.Duration
 
 	// txm config
 	TxTimeout        time.Duration
@@ -44,14 +53,27 @@ type Config interface {
 
 	// client config
 	RequestTimeout() time.Duration
+	RetryPolicy() starknet.RetryPolicy
+
+	// SequencerUptimeFeedAddress is the uptime feed gating OCR2 transmissions, empty if disabled
+	SequencerUptimeFeedAddress() string
 }
 
 type Chain struct {
 	OCR2CachePollPeriod *config.Duration
 	OCR2CacheTTL        *config.Duration
 	RequestTimeout      *config.Duration
+	// retries of requests failing with a rate limit, server or network error, waiting from RequestRetryMinWait
+	// doubling up to RequestRetryMaxWait between retries
+	RequestMaxRetries   *uint32
+	RequestRetryMinWait *config.Duration
+	RequestRetryMaxWait *config.Duration
 	TxTimeout           *config.Duration
 	ConfirmationPoll    *config.Duration
+	// optional, OCR2 transmissions are paused while this sequencer uptime feed reports the sequencer as down
+	SequencerUptimeFeedAddress *string
+	// optional, class hash of the OpenZeppelin account the TXM deploys for transmitter accounts that don't exist yet
+	AccountClassHash *string
 }
 
 func (c *Chain) SetDefaults() {
@@ -64,6 +86,16 @@ func (c *Chain) SetDefaults() {
 	if c.RequestTimeout == nil {
 		c.RequestTimeout = config.MustNewDuration(DefaultConfigSet.RequestTimeout)
 	}
+	if c.RequestMaxRetries == nil {
+		maxRetries := DefaultConfigSet.RequestMaxRetries
+		c.RequestMaxRetries = &maxRetries
+	}
+	if c.RequestRetryMinWait == nil {
+		c.RequestRetryMinWait = config.MustNewDuration(DefaultConfigSet.RequestRetryMinWait)
+	}
+	if c.RequestRetryMaxWait == nil {
+		c.RequestRetryMaxWait = config.MustNewDuration(DefaultConfigSet.RequestRetryMaxWait)
+	}
 	if c.TxTimeout == nil {
 		c.TxTimeout = config.MustNewDuration(DefaultConfigSet.TxTimeout)
 	}
@@ -72,11 +104,45 @@ func (c *Chain) SetDefaults() {
 	}
 }
 
+// RemoteSigner configures a remote signer used by the TXM instead of the node keystore, over mutual TLS
+type RemoteSigner struct {
+	URL *config.URL
+	// client certificate and key presented to the signer
+	TLSCertFile *string
+	TLSKeyFile  *string
+	// CA verifying the certificate of the signer
+	TLSCAFile *string
+}
+
+const (
+	// NodeRoleRead nodes only serve reads
+	NodeRoleRead = "read"
+	// NodeRoleSend nodes only serve the TXM, which sends transactions and reads their nonces, fees and statuses
+	NodeRoleSend = "send"
+	// NodeRoleBoth nodes serve reads and the TXM
+	NodeRoleBoth = "both"
+)
+
 type Node struct {
 	Name *string
 	URL  *config.URL
 	// optional, only if rpc url needs api key passed in header
 	APIKey *string
+	// optional, HTTP headers sent with every request, for nodes using other auth schemes
+	Headers map[string]string
+	// optional, requests per second sent to the node, unlimited if not set
+	RateLimit *float64
+	// optional, share of the requests the node receives relative to the other nodes, defaults to 1
+	Weight *uint32
+	// optional, NodeRoleRead, NodeRoleSend or NodeRoleBoth, defaults to NodeRoleBoth
+	Role *string
+}
+
+func (n *Node) role() string {
+	if n.Role == nil || *n.Role == "" {
+		return NodeRoleBoth
+	}
+	return *n.Role
 }
 
 type TOMLConfigs []*TOMLConfig
@@ -141,7 +207,9 @@ type TOMLConfig struct {
 	// Do not access directly. Use [IsEnabled]
 	Enabled *bool
 	Chain
-	Nodes Nodes
+	// optional, signs transactions with a remote signer
+	RemoteSigner *RemoteSigner
+	Nodes        Nodes
 }
 
 func (c *TOMLConfig) IsEnabled() bool {
@@ -159,6 +227,12 @@ func (c *TOMLConfig) SetFrom(f *TOMLConfig) {
 		c.FeederURL = f.FeederURL
 	}
 	setFromChain(&c.Chain, &f.Chain)
+	if f.RemoteSigner != nil {
+		if c.RemoteSigner == nil {
+			c.RemoteSigner = &RemoteSigner{}
+		}
+		setFromRemoteSigner(c.RemoteSigner, f.RemoteSigner)
+	}
 	c.Nodes.SetFrom(&f.Nodes)
 }
 
@@ -172,12 +246,42 @@ func setFromChain(c, f *Chain) {
 	if f.RequestTimeout != nil {
 		c.RequestTimeout = f.RequestTimeout
 	}
+	if f.RequestMaxRetries != nil {
+		c.RequestMaxRetries = f.RequestMaxRetries
+	}
+	if f.RequestRetryMinWait != nil {
+		c.RequestRetryMinWait = f.RequestRetryMinWait
+	}
+	if f.RequestRetryMaxWait != nil {
+		c.RequestRetryMaxWait = f.RequestRetryMaxWait
+	}
 	if f.TxTimeout != nil {
 		c.TxTimeout = f.TxTimeout
 	}
 	if f.ConfirmationPoll != nil {
 		c.ConfirmationPoll = f.ConfirmationPoll
 	}
+	if f.SequencerUptimeFeedAddress != nil {
+		c.SequencerUptimeFeedAddress = f.SequencerUptimeFeedAddress
+	}
+	if f.AccountClassHash != nil {
+		c.AccountClassHash = f.AccountClassHash
+	}
+}
+
+func setFromRemoteSigner(c, f *RemoteSigner) {
+	if f.URL != nil {
+		c.URL = f.URL
+	}
+	if f.TLSCertFile != nil {
+		c.TLSCertFile = f.TLSCertFile
+	}
+	if f.TLSKeyFile != nil {
+		c.TLSKeyFile = f.TLSKeyFile
+	}
+	if f.TLSCAFile != nil {
+		c.TLSCAFile = f.TLSCAFile
+	}
 }
 
 func (c *TOMLConfig) ValidateConfig() (err error) {
@@ -190,7 +294,85 @@ func (c *TOMLConfig) ValidateConfig() (err error) {
 	if len(c.Nodes) == 0 {
 		err = errors.Join(err, config.ErrMissing{Name: "Nodes", Msg: "must have at least one node"})
 	}
+	var readers, senders int
+	for i, n := range c.Nodes {
+		err = errors.Join(err, n.validateConfig(i))
+		switch n.role() {
+		case NodeRoleRead:
+			readers++
+		case NodeRoleSend:
+			senders++
+		case NodeRoleBoth:
+			readers++
+			senders++
+		}
+	}
+	if len(c.Nodes) > 0 && readers == 0 {
+		err = errors.Join(err, config.ErrInvalid{Name: "Nodes", Value: len(c.Nodes), Msg: "must have at least one node with the read role"})
+	}
+	if len(c.Nodes) > 0 && senders == 0 {
+		err = errors.Join(err, config.ErrInvalid{Name: "Nodes", Value: len(c.Nodes), Msg: "must have at least one node with the send role"})
+	}
+
+	if c.Chain.SequencerUptimeFeedAddress != nil && *c.Chain.SequencerUptimeFeedAddress != "" {
+		if _, err1 := starknetutils.HexToFelt(*c.Chain.SequencerUptimeFeedAddress); err1 != nil {
+			err = errors.Join(err, config.ErrInvalid{Name: "SequencerUptimeFeedAddress", Value: *c.Chain.SequencerUptimeFeedAddress, Msg: "must be a contract address"})
+		}
+	}
+
+	if c.Chain.AccountClassHash != nil && *c.Chain.AccountClassHash != "" {
+		if _, err1 := starknetutils.HexToFelt(*c.Chain.AccountClassHash); err1 != nil {
+			err = errors.Join(err, config.ErrInvalid{Name: "AccountClassHash", Value: *c.Chain.AccountClassHash, Msg: "must be a class hash"})
+		}
+	}
+
+	if c.Chain.RequestRetryMinWait != nil && c.Chain.RequestRetryMaxWait != nil &&
+		c.Chain.RequestRetryMinWait.Duration() > c.Chain.RequestRetryMaxWait.Duration() {
+		err = errors.Join(err, config.ErrInvalid{Name: "RequestRetryMinWait", Value: c.Chain.RequestRetryMinWait, Msg: "must not be greater than RequestRetryMaxWait"})
+	}
 
+	if c.RemoteSigner != nil {
+		err = errors.Join(err, c.RemoteSigner.validateConfig())
+	}
+
+	return
+}
+
+func (r *RemoteSigner) validateConfig() (err error) {
+	if r.URL == nil {
+		err = errors.Join(err, config.ErrMissing{Name: "RemoteSigner.URL", Msg: "required for the remote signer"})
+	} else if u := (*url.URL)(r.URL); u.Scheme != "https" {
+		err = errors.Join(err, config.ErrInvalid{Name: "RemoteSigner.URL", Value: u.String(), Msg: "must be https"})
+	}
+	if r.TLSCertFile == nil || *r.TLSCertFile == "" {
+		err = errors.Join(err, config.ErrMissing{Name: "RemoteSigner.TLSCertFile", Msg: "required for mutual TLS"})
+	}
+	if r.TLSKeyFile == nil || *r.TLSKeyFile == "" {
+		err = errors.Join(err, config.ErrMissing{Name: "RemoteSigner.TLSKeyFile", Msg: "required for mutual TLS"})
+	}
+	if r.TLSCAFile == nil || *r.TLSCAFile == "" {
+		err = errors.Join(err, config.ErrMissing{Name: "RemoteSigner.TLSCAFile", Msg: "required for mutual TLS"})
+	}
+	return
+}
+
+func (n *Node) validateConfig(i int) (err error) {
+	switch n.role() {
+	case NodeRoleRead, NodeRoleSend, NodeRoleBoth:
+	default:
+		err = errors.Join(err, config.ErrInvalid{Name: fmt.Sprintf("Nodes.%d.Role", i), Value: *n.Role, Msg: "must be read, send or both"})
+	}
+	if n.Weight != nil && *n.Weight == 0 {
+		err = errors.Join(err, config.ErrInvalid{Name: fmt.Sprintf("Nodes.%d.Weight", i), Value: *n.Weight, Msg: "must be positive"})
+	}
+	if n.RateLimit != nil && *n.RateLimit <= 0 {
+		err = errors.Join(err, config.ErrInvalid{Name: fmt.Sprintf("Nodes.%d.RateLimit", i), Value: *n.RateLimit, Msg: "must be positive"})
+	}
+	for name := range n.Headers {
+		if name == "" {
+			err = errors.Join(err, config.ErrInvalid{Name: fmt.Sprintf("Nodes.%d.Headers", i), Value: name, Msg: "header names can't be empty"})
+		}
+	}
 	return
 }
 
@@ -225,6 +407,21 @@ func setFromNode(n, f *Node) {
 	if f.URL != nil {
 		n.URL = f.URL
 	}
+	if f.APIKey != nil {
+		n.APIKey = f.APIKey
+	}
+	if f.Headers != nil {
+		n.Headers = f.Headers
+	}
+	if f.RateLimit != nil {
+		n.RateLimit = f.RateLimit
+	}
+	if f.Weight != nil {
+		n.Weight = f.Weight
+	}
+	if f.Role != nil {
+		n.Role = f.Role
+	}
 }
 
 func legacyNode(n *Node, id string) db.Node {
@@ -234,11 +431,23 @@ func legacyNode(n *Node, id string) db.Node {
 	} else {
 		apiKey = *n.APIKey
 	}
+	var rateLimit float64
+	if n.RateLimit != nil {
+		rateLimit = *n.RateLimit
+	}
+	weight := uint32(1)
+	if n.Weight != nil {
+		weight = *n.Weight
+	}
 	return db.Node{
-		Name:    *n.Name,
-		ChainID: id,
-		URL:     (*url.URL)(n.URL).String(),
-		APIKey:  apiKey,
+		Name:      *n.Name,
+		ChainID:   id,
+		URL:       (*url.URL)(n.URL).String(),
+		APIKey:    apiKey,
+		Headers:   n.Headers,
+		RateLimit: rateLimit,
+		Weight:    weight,
+		Role:      n.role(),
 	}
 }
 
@@ -264,6 +473,46 @@ func (c *TOMLConfig) RequestTimeout() time.Duration {
 	return c.Chain.RequestTimeout.Duration()
 }
 
+func (c *TOMLConfig) RetryPolicy() starknet.RetryPolicy {
+	return starknet.RetryPolicy{
+		MaxRetries: int(*c.Chain.RequestMaxRetries),
+		MinWait:    c.Chain.RequestRetryMinWait.Duration(),
+		MaxWait:    c.Chain.RequestRetryMaxWait.Duration(),
+		Backoff:    starknet.ExponentialBackoff,
+	}
+}
+
+func (c *TOMLConfig) SequencerUptimeFeedAddress() string {
+	if c.Chain.SequencerUptimeFeedAddress == nil {
+		return ""
+	}
+	return *c.Chain.SequencerUptimeFeedAddress
+}
+
+func (c *TOMLConfig) AccountClassHash() string {
+	if c.Chain.AccountClassHash == nil {
+		return ""
+	}
+	return *c.Chain.AccountClassHash
+}
+
+// RemoteSignerConfig returns the remote signer config, nil if the node keystore signs transactions
+func (c *TOMLConfig) RemoteSignerConfig() (*keystore.RemoteSignerConfig, error) {
+	if c.RemoteSigner == nil {
+		return nil, nil
+	}
+	if err := c.RemoteSigner.validateConfig(); err != nil {
+		return nil, err
+	}
+	return &keystore.RemoteSignerConfig{
+		URL:      (*url.URL)(c.RemoteSigner.URL).String(),
+		CertFile: *c.RemoteSigner.TLSCertFile,
+		KeyFile:  *c.RemoteSigner.TLSKeyFile,
+		CAFile:   *c.RemoteSigner.TLSCAFile,
+		Timeout:  c.RequestTimeout(),
+	}, nil
+}
+
 func (c *TOMLConfig) ListNodes() ([]db.Node, error) {
 	var allNodes []db.Node
 	for _, n := range c.Nodes {
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/config"
+)
+
+func TestTOMLConfig_RemoteSignerConfig(t *testing.T) {
+	cfg := &TOMLConfig{}
+	cfg.Chain.SetDefaults()
+
+	rs, err := cfg.RemoteSignerConfig()
+	require.NoError(t, err)
+	assert.Nil(t, rs)
+
+	// partial configs are reported instead of dereferenced
+	cfg.RemoteSigner = &RemoteSigner{URL: config.MustParseURL("https://signer.example")}
+	_, err = cfg.RemoteSignerConfig()
+	assert.ErrorContains(t, err, "RemoteSigner.TLSCertFile")
+	cfg.RemoteSigner = &RemoteSigner{}
+	_, err = cfg.RemoteSignerConfig()
+	assert.ErrorContains(t, err, "RemoteSigner.URL")
+
+	cert, key, ca := "cert.pem", "key.pem", "ca.pem"
+	cfg.RemoteSigner = &RemoteSigner{URL: config.MustParseURL("https://signer.example"), TLSCertFile: &cert, TLSKeyFile: &key, TLSCAFile: &ca}
+	rs, err = cfg.RemoteSignerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "https://signer.example", rs.URL)
+	assert.Equal(t, "cert.pem", rs.CertFile)
+	assert.Equal(t, "key.pem", rs.KeyFile)
+	assert.Equal(t, "ca.pem", rs.CAFile)
+	assert.Equal(t, DefaultConfigSet.RequestTimeout, rs.Timeout)
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/db"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+)
+
+// Reloadable is the Config of a chain that can be replaced at runtime. Every getter reads the current TOMLConfig,
+// so the TXM and OCR2 caches pick up new timings on their next tick.
+type Reloadable struct {
+	lock sync.RWMutex
+	cfg  *TOMLConfig
+}
+
+var _ Config = (*Reloadable)(nil)
+
+func NewReloadable(cfg *TOMLConfig) *Reloadable {
+	return &Reloadable{cfg: cfg}
+}
+
+// Current returns the current TOMLConfig, which must not be modified
+func (r *Reloadable) Current() *TOMLConfig {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.cfg
+}
+
+// Update validates and swaps in a new TOMLConfig. Nodes, feeder URL, timings and the account class hash can be
+// changed, the chain ID, enabled state and remote signer are fixed for the lifetime of the chain.
+func (r *Reloadable) Update(cfg *TOMLConfig) error {
+	cfg.Chain.SetDefaults()
+	if err := cfg.ValidateConfig(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	if err := (TOMLConfigs{cfg}).ValidateConfig(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if *cfg.ChainID != *r.cfg.ChainID {
+		return fmt.Errorf("chain ID can't change from %s to %s", *r.cfg.ChainID, *cfg.ChainID)
+	}
+	if cfg.IsEnabled() != r.cfg.IsEnabled() {
+		return errors.New("enabling or disabling a chain requires a restart")
+	}
+	if !reflect.DeepEqual(cfg.RemoteSigner, r.cfg.RemoteSigner) {
+		return errors.New("changing the remote signer requires a restart")
+	}
+	r.cfg = cfg
+	return nil
+}
+
+func (r *Reloadable) TxTimeout() time.Duration {
+	return r.Current().TxTimeout()
+}
+
+func (r *Reloadable) ConfirmationPoll() time.Duration {
+	return r.Current().ConfirmationPoll()
+}
+
+func (r *Reloadable) OCR2CachePollPeriod() time.Duration {
+	return r.Current().OCR2CachePollPeriod()
+}
+
+func (r *Reloadable) OCR2CacheTTL() time.Duration {
+	return r.Current().OCR2CacheTTL()
+}
+
+func (r *Reloadable) RequestTimeout() time.Duration {
+	return r.Current().RequestTimeout()
+}
+
+func (r *Reloadable) RetryPolicy() starknet.RetryPolicy {
+	return r.Current().RetryPolicy()
+}
+
+func (r *Reloadable) SequencerUptimeFeedAddress() string {
+	return r.Current().SequencerUptimeFeedAddress()
+}
+
+func (r *Reloadable) AccountClassHash() string {
+	return r.Current().AccountClassHash()
+}
+
+func (r *Reloadable) ListNodes() ([]db.Node, error) {
+	return r.Current().ListNodes()
+}
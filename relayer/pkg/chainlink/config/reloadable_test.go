@@ -0,0 +1,62 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/config"
+)
+
+func TestReloadable_Update(t *testing.T) {
+	newConfig := func(chainID string, nodes ...string) *TOMLConfig {
+		cfg := &TOMLConfig{ChainID: &chainID}
+		for _, name := range nodes {
+			name := name
+			cfg.Nodes = append(cfg.Nodes, &Node{Name: &name, URL: config.MustParseURL("https://" + name + ".example")})
+		}
+		cfg.Chain.SetDefaults()
+		return cfg
+	}
+
+	r := NewReloadable(newConfig("SN_SEPOLIA", "a"))
+	assert.Equal(t, DefaultConfigSet.ConfirmationPoll, r.ConfirmationPoll())
+	assert.Equal(t, int(DefaultConfigSet.RequestMaxRetries), r.RetryPolicy().MaxRetries)
+
+	t.Run("applies nodes and timings", func(t *testing.T) {
+		cfg := newConfig("SN_SEPOLIA", "b", "c")
+		cfg.Chain.ConfirmationPoll = config.MustNewDuration(time.Second)
+		cfg.Chain.OCR2CachePollPeriod = nil // defaulted
+		require.NoError(t, r.Update(cfg))
+
+		assert.Equal(t, time.Second, r.ConfirmationPoll())
+		assert.Equal(t, DefaultConfigSet.OCR2CachePollPeriod, r.OCR2CachePollPeriod())
+		nodes, err := r.ListNodes()
+		require.NoError(t, err)
+		require.Len(t, nodes, 2)
+		assert.Equal(t, "b", nodes[0].Name)
+	})
+
+	t.Run("rejects invalid configs", func(t *testing.T) {
+		current := r.Current()
+		assert.ErrorContains(t, r.Update(newConfig("SN_SEPOLIA")), "must have at least one node")
+		assert.ErrorContains(t, r.Update(newConfig("SN_SEPOLIA", "d", "d")), "Nodes.1.Name")
+		assert.ErrorContains(t, r.Update(newConfig("SN_MAIN", "d")), "chain ID can't change")
+
+		slowRetries := newConfig("SN_SEPOLIA", "d")
+		slowRetries.Chain.RequestRetryMinWait = config.MustNewDuration(time.Minute)
+		assert.ErrorContains(t, r.Update(slowRetries), "RequestRetryMinWait")
+
+		disabled := newConfig("SN_SEPOLIA", "d")
+		disabled.Enabled = new(bool)
+		assert.ErrorContains(t, r.Update(disabled), "requires a restart")
+
+		signer := newConfig("SN_SEPOLIA", "d")
+		signer.RemoteSigner = &RemoteSigner{URL: config.MustParseURL("https://signer.example")}
+		assert.Error(t, r.Update(signer))
+
+		assert.Same(t, current, r.Current())
+	})
+}
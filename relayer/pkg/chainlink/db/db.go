@@ -10,6 +10,10 @@ type Node struct {
 	ChainID   string `db:"starknet_chain_id"`
 	URL       string
 	APIKey    string
+	Headers   map[string]string
+	RateLimit float64 // requests per second, 0 if unlimited
+	Weight    uint32
+	Role      string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -0,0 +1,243 @@
+package keystore
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/starknet.go/curve"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/loop"
+)
+
+// ErrDecrypt is returned when a keyfile can't be decrypted with the given password
+var ErrDecrypt = errors.New("couldn't decrypt key, wrong password")
+
+// ScryptParams are the cost parameters of the key derivation, see [scrypt.Key]
+type ScryptParams struct {
+	N, R, P int
+}
+
+var (
+	// StandardScrypt takes about a second and 256MB of memory to derive a key
+	StandardScrypt = ScryptParams{N: 1 << 18, R: 8, P: 1}
+	// LightScrypt is fast enough for tests and development
+	LightScrypt = ScryptParams{N: 1 << 12, R: 8, P: 6}
+)
+
+const (
+	keyfileVersion = 1
+	scryptDKLen    = 32
+)
+
+// keyfile follows the layout of the web3 secret storage format, the mac is the keccak256 of the second half of the
+// derived key and the ciphertext
+type keyfile struct {
+	PublicKey string        `json:"publicKey"`
+	Crypto    keyfileCrypto `json:"crypto"`
+	Version   int           `json:"version"`
+}
+
+type keyfileCrypto struct {
+	Cipher       string `json:"cipher"`
+	CipherText   string `json:"ciphertext"`
+	CipherParams struct {
+		IV string `json:"iv"`
+	} `json:"cipherparams"`
+	KDF       string `json:"kdf"`
+	KDFParams struct {
+		N     int    `json:"n"`
+		R     int    `json:"r"`
+		P     int    `json:"p"`
+		DKLen int    `json:"dklen"`
+		Salt  string `json:"salt"`
+	} `json:"kdfparams"`
+	MAC string `json:"mac"`
+}
+
+// EncryptKey encrypts a private key into a JSON keyfile
+func EncryptKey(privateKey *big.Int, password string, params ScryptParams) ([]byte, error) {
+	publicKey, err := NewMemoryKeystore().Add(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 32)
+	iv := make([]byte, aes.BlockSize)
+	if _, err = rand.Read(salt); err != nil {
+		return nil, err
+	}
+	if _, err = rand.Read(iv); err != nil {
+		return nil, err
+	}
+	derivedKey, err := scrypt.Key([]byte(password), salt, params.N, params.R, params.P, scryptDKLen)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't derive key: %w", err)
+	}
+	cipherText, err := aesCTR(derivedKey[:16], iv, privateKey.FillBytes(make([]byte, 32)))
+	if err != nil {
+		return nil, err
+	}
+
+	k := keyfile{PublicKey: publicKey.String(), Version: keyfileVersion}
+	k.Crypto.Cipher = "aes-128-ctr"
+	k.Crypto.CipherText = hex.EncodeToString(cipherText)
+	k.Crypto.CipherParams.IV = hex.EncodeToString(iv)
+	k.Crypto.KDF = "scrypt"
+	k.Crypto.KDFParams.N = params.N
+	k.Crypto.KDFParams.R = params.R
+	k.Crypto.KDFParams.P = params.P
+	k.Crypto.KDFParams.DKLen = scryptDKLen
+	k.Crypto.KDFParams.Salt = hex.EncodeToString(salt)
+	k.Crypto.MAC = hex.EncodeToString(crypto.Keccak256(derivedKey[16:32], cipherText))
+	return json.MarshalIndent(k, "", "  ")
+}
+
+// DecryptKey decrypts a JSON keyfile, returning ErrDecrypt if the password is wrong
+func DecryptKey(keyJSON []byte, password string) (*big.Int, error) {
+	var k keyfile
+	if err := json.Unmarshal(keyJSON, &k); err != nil {
+		return nil, fmt.Errorf("couldn't parse keyfile: %w", err)
+	}
+	if k.Version != keyfileVersion {
+		return nil, fmt.Errorf("unsupported keyfile version %d", k.Version)
+	}
+	if k.Crypto.Cipher != "aes-128-ctr" || k.Crypto.KDF != "scrypt" {
+		return nil, fmt.Errorf("unsupported cipher %s or kdf %s", k.Crypto.Cipher, k.Crypto.KDF)
+	}
+
+	params := k.Crypto.KDFParams
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt: %w", err)
+	}
+	iv, err := hex.DecodeString(k.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, fmt.Errorf("invalid iv: %w", err)
+	}
+	cipherText, err := hex.DecodeString(k.Crypto.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ciphertext: %w", err)
+	}
+	mac, err := hex.DecodeString(k.Crypto.MAC)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mac: %w", err)
+	}
+	if params.DKLen != scryptDKLen {
+		return nil, fmt.Errorf("unsupported derived key length %d", params.DKLen)
+	}
+
+	derivedKey, err := scrypt.Key([]byte(password), salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't derive key: %w", err)
+	}
+	if !bytes.Equal(crypto.Keccak256(derivedKey[16:32], cipherText), mac) {
+		return nil, ErrDecrypt
+	}
+	plainText, err := aesCTR(derivedKey[:16], iv, cipherText)
+	if err != nil {
+		return nil, err
+	}
+	privateKey := new(big.Int).SetBytes(plainText)
+	if privateKey.Sign() <= 0 || privateKey.Cmp(curve.Curve.N) >= 0 {
+		return nil, errors.New("private key out of range")
+	}
+	return privateKey, nil
+}
+
+func aesCTR(key, iv, in []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+	return out, nil
+}
+
+var _ loop.Keystore = (*FileKeystore)(nil)
+
+// FileKeystore is a loop.Keystore backed by a directory of keyfiles encrypted with a single password. The keys are
+// decrypted once when the keystore is opened and held in memory.
+type FileKeystore struct {
+	dir      string
+	password string
+	params   ScryptParams
+	keys     *MemoryKeystore
+}
+
+// NewFileKeystore opens the keyfiles (*.json) in dir, the directory is created if it doesn't exist
+func NewFileKeystore(dir, password string, params ScryptParams) (*FileKeystore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("couldn't create keystore directory: %w", err)
+	}
+	ks := &FileKeystore{dir: dir, password: password, params: params, keys: NewMemoryKeystore()}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		keyJSON, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read keyfile %s: %w", path, err)
+		}
+		privateKey, err := DecryptKey(keyJSON, password)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't decrypt keyfile %s: %w", path, err)
+		}
+		if _, err = ks.keys.Add(privateKey); err != nil {
+			return nil, fmt.Errorf("invalid key in keyfile %s: %w", path, err)
+		}
+	}
+	return ks, nil
+}
+
+// Add encrypts and stores a private key, returning its public key
+func (ks *FileKeystore) Add(privateKey *big.Int) (*felt.Felt, error) {
+	keyJSON, err := EncryptKey(privateKey, ks.password, ks.params)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := ks.keys.Add(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(ks.dir, strings.TrimPrefix(publicKey.String(), "0x")+".json")
+	if err = os.WriteFile(path, keyJSON, 0o600); err != nil {
+		return nil, fmt.Errorf("couldn't write keyfile: %w", err)
+	}
+	return publicKey, nil
+}
+
+// Create generates and stores a new private key, returning its public key
+func (ks *FileKeystore) Create() (*felt.Felt, error) {
+	privateKey, err := curve.Curve.GetRandomPrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate private key: %w", err)
+	}
+	return ks.Add(privateKey)
+}
+
+// Sign implements [loop.Keystore], see [MemoryKeystore.Sign]
+func (ks *FileKeystore) Sign(ctx context.Context, id string, hash []byte) ([]byte, error) {
+	return ks.keys.Sign(ctx, id, hash)
+}
+
+// Accounts implements [loop.Keystore], listing the public keys in the keystore
+func (ks *FileKeystore) Accounts(ctx context.Context) ([]string, error) {
+	return ks.keys.Accounts(ctx)
+}
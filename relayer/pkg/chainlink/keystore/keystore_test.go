@@ -0,0 +1,104 @@
+package keystore_test
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NethermindEth/starknet.go/curve"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/keystore"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/txm"
+)
+
+func TestMemoryKeystore(t *testing.T) {
+	ctx := tests.Context(t)
+	ks := keystore.NewMemoryKeystore()
+
+	privateKey := starknetutils.HexToBN(txm.PrivateKeys0Seed[0])
+	publicKey, err := ks.Add(privateKey)
+	require.NoError(t, err)
+	x, y, err := curve.Curve.PrivateToPoint(privateKey)
+	require.NoError(t, err)
+	assert.Equal(t, starknetutils.BigIntToFelt(x), publicKey)
+
+	created, err := ks.Create()
+	require.NoError(t, err)
+	accounts, err := ks.Accounts(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{publicKey.String(), created.String()}, accounts)
+
+	t.Run("signs through the txm adapter", func(t *testing.T) {
+		hash := big.NewInt(42)
+		r, s, err := txm.NewKeystoreAdapter(ks).Sign(ctx, publicKey.String(), hash)
+		require.NoError(t, err)
+		assert.True(t, curve.Curve.Verify(hash, r, s, x, y))
+	})
+
+	t.Run("matches padded keys", func(t *testing.T) {
+		padded := "0x" + strings.Repeat("0", 64-len(publicKey.String()[2:])) + strings.ToUpper(publicKey.String()[2:])
+		_, err := ks.Sign(ctx, padded, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := ks.Sign(ctx, "0x1", []byte{1})
+		require.ErrorIs(t, err, keystore.ErrUnknownKey)
+	})
+
+	t.Run("rejects out of range keys", func(t *testing.T) {
+		_, err := ks.Add(big.NewInt(0))
+		require.Error(t, err)
+		_, err = ks.Add(curve.Curve.N)
+		require.Error(t, err)
+	})
+}
+
+func TestKeyfile(t *testing.T) {
+	privateKey := starknetutils.HexToBN(txm.PrivateKeys0Seed[1])
+	keyJSON, err := keystore.EncryptKey(privateKey, "password", keystore.LightScrypt)
+	require.NoError(t, err)
+	assert.NotContains(t, string(keyJSON), privateKey.Text(16))
+
+	decrypted, err := keystore.DecryptKey(keyJSON, "password")
+	require.NoError(t, err)
+	assert.Equal(t, privateKey, decrypted)
+
+	_, err = keystore.DecryptKey(keyJSON, "wrong")
+	require.ErrorIs(t, err, keystore.ErrDecrypt)
+}
+
+func TestFileKeystore(t *testing.T) {
+	ctx := tests.Context(t)
+	dir := filepath.Join(t.TempDir(), "keys")
+
+	ks, err := keystore.NewFileKeystore(dir, "password", keystore.LightScrypt)
+	require.NoError(t, err)
+	created, err := ks.Create()
+	require.NoError(t, err)
+	imported, err := ks.Add(starknetutils.HexToBN(txm.PrivateKeys0Seed[2]))
+	require.NoError(t, err)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	reopened, err := keystore.NewFileKeystore(dir, "password", keystore.LightScrypt)
+	require.NoError(t, err)
+	accounts, err := reopened.Accounts(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{created.String(), imported.String()}, accounts)
+
+	_, _, err = txm.NewKeystoreAdapter(reopened).Sign(ctx, created.String(), big.NewInt(42))
+	require.NoError(t, err)
+
+	_, err = keystore.NewFileKeystore(dir, "wrong", keystore.LightScrypt)
+	require.ErrorIs(t, err, keystore.ErrDecrypt)
+}
@@ -0,0 +1,107 @@
+package keystore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/starknet.go/curve"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/loop"
+	adapters "github.com/smartcontractkit/chainlink-common/pkg/loop/adapters/starknet"
+)
+
+// ErrUnknownKey is returned when signing with a public key the keystore doesn't hold
+var ErrUnknownKey = errors.New("unknown key")
+
+var _ loop.Keystore = (*MemoryKeystore)(nil)
+
+// MemoryKeystore is a loop.Keystore holding Stark private keys in memory, keyed by the hex encoding of their public
+// key. Signatures are encoded as [adapters.Signature], as the txm.KeystoreAdapter expects.
+type MemoryKeystore struct {
+	lock sync.RWMutex
+	keys map[string]*big.Int
+}
+
+func NewMemoryKeystore() *MemoryKeystore {
+	return &MemoryKeystore{keys: map[string]*big.Int{}}
+}
+
+// Add imports a private key and returns its public key
+func (ks *MemoryKeystore) Add(privateKey *big.Int) (*felt.Felt, error) {
+	if privateKey.Sign() <= 0 || privateKey.Cmp(curve.Curve.N) >= 0 {
+		return nil, errors.New("private key out of range")
+	}
+	x, _, err := curve.Curve.PrivateToPoint(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't derive public key: %w", err)
+	}
+	publicKey := starknetutils.BigIntToFelt(x)
+
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	ks.keys[publicKey.String()] = new(big.Int).Set(privateKey)
+	return publicKey, nil
+}
+
+// Create generates a new private key and returns its public key
+func (ks *MemoryKeystore) Create() (*felt.Felt, error) {
+	privateKey, err := curve.Curve.GetRandomPrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate private key: %w", err)
+	}
+	return ks.Add(privateKey)
+}
+
+// Sign implements [loop.Keystore]. The id is the public key and hash the big-endian bytes of the message hash, a nil
+// hash only checks that the key exists.
+func (ks *MemoryKeystore) Sign(_ context.Context, id string, hash []byte) ([]byte, error) {
+	privateKey, err := ks.privateKey(id)
+	if err != nil {
+		return nil, err
+	}
+	if hash == nil {
+		return nil, nil
+	}
+	r, s, err := curve.Curve.Sign(new(big.Int).SetBytes(hash), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't sign: %w", err)
+	}
+	sig, err := adapters.SignatureFromBigInts(r, s)
+	if err != nil {
+		return nil, err
+	}
+	return sig.Bytes()
+}
+
+// Accounts implements [loop.Keystore], listing the public keys in the keystore
+func (ks *MemoryKeystore) Accounts(context.Context) ([]string, error) {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	accounts := make([]string, 0, len(ks.keys))
+	for publicKey := range ks.keys {
+		accounts = append(accounts, publicKey)
+	}
+	sort.Strings(accounts)
+	return accounts, nil
+}
+
+func (ks *MemoryKeystore) privateKey(id string) (*big.Int, error) {
+	// normalized, so padded or upper case keys match
+	publicKey, err := starknetutils.HexToFelt(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key id %q: %w", id, err)
+	}
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	privateKey, ok := ks.keys[publicKey.String()]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnknownKey, id)
+	}
+	return privateKey, nil
+}
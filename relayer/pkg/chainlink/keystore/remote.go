@@ -0,0 +1,221 @@
+package keystore
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/loop"
+	adapters "github.com/smartcontractkit/chainlink-common/pkg/loop/adapters/starknet"
+)
+
+var (
+	// ErrNoSignRequest is returned when a remote signature is requested without the transaction being signed
+	ErrNoSignRequest = errors.New("remote signer requires the transaction being signed")
+	// ErrSignerRejected is returned when the remote signer refuses to sign a transaction, because of its allowlist
+	ErrSignerRejected = errors.New("remote signer rejected the transaction")
+)
+
+// The remote signer API is JSON over HTTPS with mutual TLS:
+//
+//	GET  /v1/accounts -> accountsResponse
+//	POST /v1/sign     signRequest -> signResponse
+//
+// Errors are returned as errorResponse with a 4xx or 5xx status, 403 if the signer's policy rejects the transaction.
+const (
+	accountsPath = "/v1/accounts"
+	signPath     = "/v1/sign"
+)
+
+type accountsResponse struct {
+	PublicKeys []string `json:"publicKeys"`
+}
+
+type signRequest struct {
+	PublicKey      *felt.Felt                      `json:"publicKey"`
+	Hash           *felt.Felt                      `json:"hash"`
+	AccountAddress *felt.Felt                      `json:"accountAddress"`
+	Calls          []starknetrpc.FunctionCall      `json:"calls,omitempty"`
+	Invoke         *starknetrpc.InvokeTxnV3        `json:"invoke,omitempty"`
+	DeployAccount  *starknetrpc.DeployAccountTxnV3 `json:"deployAccount,omitempty"`
+}
+
+type signResponse struct {
+	R *felt.Felt `json:"r"`
+	S *felt.Felt `json:"s"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// RemoteSignerConfig configures the connection to a remote signer
+type RemoteSignerConfig struct {
+	// URL of the signer, https is required
+	URL string
+	// CertFile and KeyFile are the client certificate presented to the signer
+	CertFile string
+	KeyFile  string
+	// CAFile verifies the certificate of the signer
+	CAFile  string
+	Timeout time.Duration
+}
+
+var _ loop.Keystore = (*RemoteKeystore)(nil)
+
+// RemoteKeystore is a loop.Keystore delegating signatures to a remote signing service, so the keys never reside on
+// the node host. Only transactions of the TXM can be signed, as the signer checks the transaction behind each hash
+// against its allowlist, see [SignRequest].
+type RemoteKeystore struct {
+	url    string
+	client *http.Client
+}
+
+func NewRemoteKeystore(cfg RemoteSignerConfig) (*RemoteKeystore, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote signer URL: %w", err)
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("remote signer URL must be https, got %q", u.Scheme)
+	}
+	cert, pool, err := loadTLS(cfg.CertFile, cfg.KeyFile, cfg.CAFile)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}
+	return &RemoteKeystore{
+		url:    strings.TrimSuffix(u.String(), "/"),
+		client: &http.Client{Transport: transport, Timeout: cfg.Timeout},
+	}, nil
+}
+
+// Sign implements [loop.Keystore]. The context has to carry the [SignRequest] of the hash, a nil hash only checks
+// that the signer holds the key.
+func (ks *RemoteKeystore) Sign(ctx context.Context, id string, hash []byte) ([]byte, error) {
+	publicKey, err := starknetutils.HexToFelt(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key id %q: %w", id, err)
+	}
+	if hash == nil {
+		accounts, err := ks.Accounts(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, account := range accounts {
+			if key, err := starknetutils.HexToFelt(account); err == nil && key.Equal(publicKey) {
+				return nil, nil
+			}
+		}
+		return nil, fmt.Errorf("%w %s", ErrUnknownKey, id)
+	}
+
+	req, ok := SignRequestFromContext(ctx)
+	if !ok {
+		return nil, ErrNoSignRequest
+	}
+	var res signResponse
+	err = ks.do(ctx, http.MethodPost, signPath, signRequest{
+		PublicKey:      publicKey,
+		Hash:           new(felt.Felt).SetBytes(hash),
+		AccountAddress: req.AccountAddress,
+		Calls:          req.Calls,
+		Invoke:         req.Invoke,
+		DeployAccount:  req.DeployAccount,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	if res.R == nil || res.S == nil {
+		return nil, errors.New("remote signer returned an incomplete signature")
+	}
+	sig, err := adapters.SignatureFromBigInts(res.R.BigInt(new(big.Int)), res.S.BigInt(new(big.Int)))
+	if err != nil {
+		return nil, err
+	}
+	return sig.Bytes()
+}
+
+// Accounts implements [loop.Keystore], listing the public keys held by the signer
+func (ks *RemoteKeystore) Accounts(ctx context.Context) ([]string, error) {
+	var res accountsResponse
+	if err := ks.do(ctx, http.MethodGet, accountsPath, nil, &res); err != nil {
+		return nil, err
+	}
+	return res.PublicKeys, nil
+}
+
+func (ks *RemoteKeystore) do(ctx context.Context, method, path string, body, result any) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("couldn't encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, ks.url+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := ks.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("remote signer request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		var errRes errorResponse
+		_ = json.NewDecoder(res.Body).Decode(&errRes)
+		if res.StatusCode == http.StatusForbidden {
+			return fmt.Errorf("%w: %s", ErrSignerRejected, errRes.Error)
+		}
+		return fmt.Errorf("remote signer returned status %d: %s", res.StatusCode, errRes.Error)
+	}
+	if err = json.NewDecoder(res.Body).Decode(result); err != nil {
+		return fmt.Errorf("couldn't decode remote signer response: %w", err)
+	}
+	return nil
+}
+
+// loadTLS loads a certificate and the pool of the CA verifying the other side of the connection
+func loadTLS(certFile, keyFile, caFile string) (tls.Certificate, *x509.CertPool, error) {
+	if certFile == "" || keyFile == "" || caFile == "" {
+		return tls.Certificate{}, nil, errors.New("a certificate, key and CA are required for mutual TLS")
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("couldn't load certificate: %w", err)
+	}
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("couldn't read CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return tls.Certificate{}, nil, fmt.Errorf("no certificates found in CA file %s", caFile)
+	}
+	return cert, pool, nil
+}
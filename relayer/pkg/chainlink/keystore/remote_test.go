@@ -0,0 +1,238 @@
+package keystore_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetaccount "github.com/NethermindEth/starknet.go/account"
+	"github.com/NethermindEth/starknet.go/curve"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/keystore"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/txm"
+)
+
+func TestRemoteKeystore(t *testing.T) {
+	ctx := tests.Context(t)
+	chainID := "SN_SEPOLIA"
+	accountAddress := starknetutils.TestHexToFelt(t, "0x123")
+	aggregator := starknetutils.TestHexToFelt(t, "0x456")
+	transmit := starknetutils.GetSelectorFromNameFelt("transmit")
+
+	privateKey := starknetutils.HexToBN(txm.PrivateKeys0Seed[0])
+	local := keystore.NewMemoryKeystore()
+	publicKey, err := local.Add(privateKey)
+	require.NoError(t, err)
+
+	signer := keystore.NewSigner(logger.Test(t), local, chainID, keystore.SignerPolicy{
+		Allowlist: []keystore.AllowedCall{{ContractAddress: aggregator, Selector: transmit}},
+	})
+	dir := t.TempDir()
+	writeCA(t, dir, "ca")
+	writeCert(t, dir, "ca", "server")
+	writeCert(t, dir, "ca", "client")
+	tlsCfg, err := keystore.SignerTLSConfig(filepath.Join(dir, "server.pem"), filepath.Join(dir, "server-key.pem"), filepath.Join(dir, "ca.pem"))
+	require.NoError(t, err)
+	server := httptest.NewUnstartedServer(signer)
+	server.TLS = tlsCfg
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	remote, err := keystore.NewRemoteKeystore(keystore.RemoteSignerConfig{
+		URL:      server.URL,
+		CertFile: filepath.Join(dir, "client.pem"),
+		KeyFile:  filepath.Join(dir, "client-key.pem"),
+		CAFile:   filepath.Join(dir, "ca.pem"),
+		Timeout:  5 * time.Second,
+	})
+	require.NoError(t, err)
+
+	invoke := func(calls ...starknetrpc.FunctionCall) (starknetrpc.InvokeTxnV3, *felt.Felt) {
+		tx := starknetrpc.InvokeTxnV3{
+			Type:          starknetrpc.TransactionType_Invoke,
+			SenderAddress: accountAddress,
+			Calldata:      starknetaccount.FmtCallDataCairo2(calls),
+			Version:       starknetrpc.TransactionV3,
+			Signature:     []*felt.Felt{},
+			Nonce:         new(felt.Felt).SetUint64(3),
+			ResourceBounds: starknetrpc.ResourceBoundsMapping{
+				L1Gas: starknetrpc.ResourceBounds{MaxAmount: "0x10", MaxPricePerUnit: "0x20"},
+				L2Gas: starknetrpc.ResourceBounds{MaxAmount: "0x0", MaxPricePerUnit: "0x0"},
+			},
+			Tip:                   "0x0",
+			PayMasterData:         []*felt.Felt{},
+			AccountDeploymentData: []*felt.Felt{},
+			NonceDataMode:         starknetrpc.DAModeL1,
+			FeeMode:               starknetrpc.DAModeL1,
+		}
+		hash, err := (&starknetaccount.Account{ChainId: new(felt.Felt).SetBytes([]byte(chainID))}).TransactionHashInvoke(tx)
+		require.NoError(t, err)
+		return tx, hash
+	}
+	sign := func(req keystore.SignRequest, hash *felt.Felt) (*big.Int, *big.Int, error) {
+		return txm.NewKeystoreAdapter(remote).Sign(keystore.WithSignRequest(ctx, req), publicKey.String(), starknetutils.FeltToBigInt(hash))
+	}
+
+	t.Run("accounts", func(t *testing.T) {
+		accounts, err := remote.Accounts(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{publicKey.String()}, accounts)
+
+		_, err = remote.Sign(ctx, publicKey.String(), nil)
+		require.NoError(t, err)
+		_, err = remote.Sign(ctx, "0x1", nil)
+		require.ErrorIs(t, err, keystore.ErrUnknownKey)
+	})
+
+	t.Run("signs allowed calls", func(t *testing.T) {
+		call := starknetrpc.FunctionCall{ContractAddress: aggregator, EntryPointSelector: transmit, Calldata: []*felt.Felt{new(felt.Felt).SetUint64(1)}}
+		tx, hash := invoke(call)
+		r, s, err := sign(keystore.SignRequest{AccountAddress: accountAddress, Calls: []starknetrpc.FunctionCall{call}, Invoke: &tx}, hash)
+		require.NoError(t, err)
+		x, y, err := curve.Curve.PrivateToPoint(privateKey)
+		require.NoError(t, err)
+		assert.True(t, curve.Curve.Verify(starknetutils.FeltToBigInt(hash), r, s, x, y))
+	})
+
+	t.Run("rejects calls outside the allowlist", func(t *testing.T) {
+		call := starknetrpc.FunctionCall{ContractAddress: aggregator, EntryPointSelector: starknetutils.GetSelectorFromNameFelt("transfer_ownership"), Calldata: []*felt.Felt{}}
+		tx, hash := invoke(call)
+		_, _, err := sign(keystore.SignRequest{AccountAddress: accountAddress, Calls: []starknetrpc.FunctionCall{call}, Invoke: &tx}, hash)
+		require.ErrorIs(t, err, keystore.ErrSignerRejected)
+	})
+
+	t.Run("rejects hashes of other transactions", func(t *testing.T) {
+		call := starknetrpc.FunctionCall{ContractAddress: aggregator, EntryPointSelector: transmit, Calldata: []*felt.Felt{}}
+		tx, _ := invoke(call)
+		_, _, err := sign(keystore.SignRequest{AccountAddress: accountAddress, Calls: []starknetrpc.FunctionCall{call}, Invoke: &tx}, new(felt.Felt).SetUint64(42))
+		require.ErrorContains(t, err, "doesn't match transaction hash")
+	})
+
+	t.Run("rejects account deployments", func(t *testing.T) {
+		tx := starknetrpc.DeployAccountTxnV3{
+			Type:                starknetrpc.TransactionType_DeployAccount,
+			Version:             starknetrpc.TransactionV3,
+			Signature:           []*felt.Felt{},
+			Nonce:               &felt.Zero,
+			ContractAddressSalt: publicKey,
+			ConstructorCalldata: []*felt.Felt{publicKey},
+			ClassHash:           new(felt.Felt).SetUint64(7),
+			Tip:                 "0x0",
+			PayMasterData:       []*felt.Felt{},
+			NonceDataMode:       starknetrpc.DAModeL1,
+			FeeMode:             starknetrpc.DAModeL1,
+		}
+		_, _, err := sign(keystore.SignRequest{AccountAddress: accountAddress, DeployAccount: &tx}, new(felt.Felt).SetUint64(42))
+		require.ErrorIs(t, err, keystore.ErrSignerRejected)
+	})
+
+	t.Run("requires the transaction", func(t *testing.T) {
+		_, err := remote.Sign(ctx, publicKey.String(), []byte{1})
+		require.ErrorIs(t, err, keystore.ErrNoSignRequest)
+	})
+
+	t.Run("requires a client certificate from the CA", func(t *testing.T) {
+		writeCA(t, dir, "other-ca")
+		writeCert(t, dir, "other-ca", "other-client")
+		other, err := keystore.NewRemoteKeystore(keystore.RemoteSignerConfig{
+			URL:      server.URL,
+			CertFile: filepath.Join(dir, "other-client.pem"),
+			KeyFile:  filepath.Join(dir, "other-client-key.pem"),
+			CAFile:   filepath.Join(dir, "ca.pem"),
+			Timeout:  5 * time.Second,
+		})
+		require.NoError(t, err)
+		_, err = other.Accounts(ctx)
+		require.Error(t, err)
+	})
+}
+
+// writeCA writes a self-signed CA to <name>.pem and <name>-key.pem
+func writeCA(t *testing.T, dir, name string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	writePEM(t, dir, name, der, key)
+}
+
+// writeCert writes a certificate for 127.0.0.1 signed by the CA to <name>.pem and <name>-key.pem
+func writeCert(t *testing.T, dir, ca, name string) {
+	caCert, err := tlsKeyPair(dir, ca)
+	require.NoError(t, err)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert.cert, &key.PublicKey, caCert.key)
+	require.NoError(t, err)
+	writePEM(t, dir, name, der, key)
+}
+
+type keyPair struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func tlsKeyPair(dir, name string) (keyPair, error) {
+	certPEM, err := os.ReadFile(filepath.Join(dir, name+".pem"))
+	if err != nil {
+		return keyPair{}, err
+	}
+	keyPEM, err := os.ReadFile(filepath.Join(dir, name+"-key.pem"))
+	if err != nil {
+		return keyPair{}, err
+	}
+	certBlock, _ := pem.Decode(certPEM)
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return keyPair{}, err
+	}
+	keyBlock, _ := pem.Decode(keyPEM)
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return keyPair{}, err
+	}
+	return keyPair{cert: cert, key: key}, nil
+}
+
+func writePEM(t *testing.T, dir, name string, der []byte, key *ecdsa.PrivateKey) {
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".pem"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+"-key.pem"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+}
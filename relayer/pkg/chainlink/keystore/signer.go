@@ -0,0 +1,218 @@
+package keystore
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetaccount "github.com/NethermindEth/starknet.go/account"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/loop"
+	adapters "github.com/smartcontractkit/chainlink-common/pkg/loop/adapters/starknet"
+)
+
+// AllowedCall is an entry of the signer allowlist, a nil Selector allows every function of the contract
+type AllowedCall struct {
+	ContractAddress *felt.Felt
+	Selector        *felt.Felt
+}
+
+// SignerPolicy restricts the transactions a [Signer] signs
+type SignerPolicy struct {
+	// Allowlist of the calls invoke transactions can make, every call of a transaction has to be allowed
+	Allowlist []AllowedCall
+	// AllowDeployAccount allows signing account deployments
+	AllowDeployAccount bool
+}
+
+func (p SignerPolicy) allows(call starknetrpc.FunctionCall) bool {
+	for _, allowed := range p.Allowlist {
+		if allowed.ContractAddress.Equal(call.ContractAddress) &&
+			(allowed.Selector == nil || allowed.Selector.Equal(call.EntryPointSelector)) {
+			return true
+		}
+	}
+	return false
+}
+
+var errPolicy = errors.New("policy violation")
+
+// Signer is a minimal remote signer serving the API of [RemoteKeystore] from a loop.Keystore, for tests and local
+// development. It only signs a hash after recomputing it from the transaction in the request, and checking the
+// transaction against its policy. Serve it with [SignerTLSConfig] to require client certificates.
+type Signer struct {
+	lggr    logger.Logger
+	ks      loop.Keystore
+	policy  SignerPolicy
+	account *starknetaccount.Account // only hashes transactions
+	mux     *http.ServeMux
+}
+
+var _ http.Handler = (*Signer)(nil)
+
+// NewSigner creates a signer for the transactions of the chain with the given ID, SN_SEPOLIA for example
+func NewSigner(lggr logger.Logger, ks loop.Keystore, chainID string, policy SignerPolicy) *Signer {
+	s := &Signer{
+		lggr:    logger.Named(lggr, "Signer"),
+		ks:      ks,
+		policy:  policy,
+		account: &starknetaccount.Account{ChainId: new(felt.Felt).SetBytes([]byte(chainID))},
+		mux:     http.NewServeMux(),
+	}
+	s.mux.HandleFunc(accountsPath, s.handleAccounts)
+	s.mux.HandleFunc(signPath, s.handleSign)
+	return s
+}
+
+func (s *Signer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Signer) handleAccounts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	publicKeys, err := s.ks.Accounts(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, accountsResponse{PublicKeys: publicKeys})
+}
+
+func (s *Signer) handleSign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	var req signRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("couldn't decode request: %w", err))
+		return
+	}
+	if req.PublicKey == nil || req.Hash == nil || req.AccountAddress == nil {
+		writeError(w, http.StatusBadRequest, errors.New("publicKey, hash and accountAddress are required"))
+		return
+	}
+
+	if err := s.check(req); err != nil {
+		s.lggr.Warnw("refused to sign", "accountAddress", req.AccountAddress, "publicKey", req.PublicKey, "err", err)
+		status := http.StatusBadRequest
+		if errors.Is(err, errPolicy) {
+			status = http.StatusForbidden
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	raw, err := s.ks.Sign(r.Context(), req.PublicKey.String(), feltBytes(req.Hash))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("couldn't sign: %w", err))
+		return
+	}
+	sig, err := adapters.SignatureFromBytes(raw)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	r1, s1, err := sig.Ints()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	s.lggr.Infow("signed", "accountAddress", req.AccountAddress, "publicKey", req.PublicKey, "hash", req.Hash)
+	writeJSON(w, http.StatusOK, signResponse{R: starknetutils.BigIntToFelt(r1), S: starknetutils.BigIntToFelt(s1)})
+}
+
+// check verifies that the hash is the hash of the transaction and that the policy allows the transaction
+func (s *Signer) check(req signRequest) error {
+	var hash *felt.Felt
+	var err error
+	switch {
+	case req.Invoke != nil:
+		if req.Invoke.SenderAddress == nil || !req.Invoke.SenderAddress.Equal(req.AccountAddress) {
+			return fmt.Errorf("sender %s is not account %s", req.Invoke.SenderAddress, req.AccountAddress)
+		}
+		if len(req.Calls) == 0 {
+			return errors.New("calls are required for invoke transactions")
+		}
+		for _, call := range req.Calls {
+			if call.ContractAddress == nil || call.EntryPointSelector == nil {
+				return errors.New("calls require a contract address and selector")
+			}
+		}
+		calldata := starknetaccount.FmtCallDataCairo2(req.Calls)
+		if !equalFelts(calldata, req.Invoke.Calldata) {
+			return errors.New("calls don't match the calldata of the transaction")
+		}
+		for _, call := range req.Calls {
+			if !s.policy.allows(call) {
+				return fmt.Errorf("%w: call to %s selector %s is not allowed", errPolicy, call.ContractAddress, call.EntryPointSelector)
+			}
+		}
+		hash, err = s.account.TransactionHashInvoke(*req.Invoke)
+	case req.DeployAccount != nil:
+		if !s.policy.AllowDeployAccount {
+			return fmt.Errorf("%w: account deployments are not allowed", errPolicy)
+		}
+		hash, err = s.account.TransactionHashDeployAccount(*req.DeployAccount, req.AccountAddress)
+	default:
+		return errors.New("an invoke or deployAccount transaction is required")
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't hash transaction: %w", err)
+	}
+	if !hash.Equal(req.Hash) {
+		return fmt.Errorf("hash %s doesn't match transaction hash %s", req.Hash, hash)
+	}
+	return nil
+}
+
+// SignerTLSConfig is the TLS config of a signer requiring client certificates issued by the CA in clientCAFile
+func SignerTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	cert, pool, err := loadTLS(certFile, keyFile, clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+func equalFelts(a, b []*felt.Felt) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+// feltBytes is the big-endian encoding of a felt as a hash for loop.Keystore.Sign
+func feltBytes(f *felt.Felt) []byte {
+	return f.BigInt(new(big.Int)).Bytes()
+}
@@ -0,0 +1,33 @@
+package keystore
+
+import (
+	"context"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+)
+
+// SignRequest is the transaction behind a hash given to [loop.Keystore.Sign]. The TXM attaches it to the signing
+// context, so keystores can check what they sign before signing it, see [RemoteKeystore].
+type SignRequest struct {
+	// AccountAddress is the sender of the invoke or the deployed account
+	AccountAddress *felt.Felt
+	// Calls are encoded in the calldata of Invoke
+	Calls  []starknetrpc.FunctionCall
+	Invoke *starknetrpc.InvokeTxnV3
+	// DeployAccount is set instead of Invoke for account deployments
+	DeployAccount *starknetrpc.DeployAccountTxnV3
+}
+
+type signRequestKey struct{}
+
+// WithSignRequest attaches the transaction being signed to the context
+func WithSignRequest(ctx context.Context, req SignRequest) context.Context {
+	return context.WithValue(ctx, signRequestKey{}, req)
+}
+
+// SignRequestFromContext returns the transaction attached by [WithSignRequest]
+func SignRequestFromContext(ctx context.Context) (SignRequest, bool) {
+	req, ok := ctx.Value(signRequestKey{}).(SignRequest)
+	return req, ok
+}
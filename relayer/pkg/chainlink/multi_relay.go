@@ -0,0 +1,224 @@
+package chainlink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/chains"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	relaytypes "github.com/smartcontractkit/chainlink-common/pkg/types"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/core"
+
+	starkchain "github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/chain"
+)
+
+var _ relaytypes.Relayer = (*multiRelayer)(nil) //nolint:staticcheck
+
+// multiRelayer serves several starknet chains from a single plugin process. Every chain keeps its own relayer, with
+// its own node pool, TXM and state caches, and providers are routed to one of them by the chainID of the relay config.
+type multiRelayer struct {
+	// in config order
+	chainIDs []string
+	relayers map[string]*relayer
+
+	lggr logger.Logger
+}
+
+func NewMultiRelayer(lggr logger.Logger, chains []starkchain.Chain, capRegistry core.CapabilitiesRegistry) (*multiRelayer, error) {
+	if len(chains) == 0 {
+		return nil, errors.New("at least one chain is required")
+	}
+	m := &multiRelayer{
+		relayers: make(map[string]*relayer, len(chains)),
+		lggr:     logger.Named(lggr, "MultiRelayer"),
+	}
+	for _, chain := range chains {
+		if _, ok := m.relayers[chain.ID()]; ok {
+			return nil, fmt.Errorf("duplicate chain %s", chain.ID())
+		}
+		m.chainIDs = append(m.chainIDs, chain.ID())
+		// named by chain so the health reports of the chains don't overwrite each other
+		m.relayers[chain.ID()] = NewRelayer(logger.Named(lggr, chain.ID()), chain, capRegistry)
+	}
+	return m, nil
+}
+
+func (m *multiRelayer) Name() string {
+	return m.lggr.Name()
+}
+
+func (m *multiRelayer) Start(ctx context.Context) error {
+	for _, id := range m.chainIDs {
+		if err := m.relayers[id].Start(ctx); err != nil {
+			return fmt.Errorf("couldn't start relayer for chain %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+func (m *multiRelayer) Close() (err error) {
+	for _, id := range m.chainIDs {
+		err = errors.Join(err, m.relayers[id].Close())
+	}
+	return err
+}
+
+func (m *multiRelayer) Ready() (err error) {
+	for _, id := range m.chainIDs {
+		err = errors.Join(err, m.relayers[id].Ready())
+	}
+	return err
+}
+
+func (m *multiRelayer) HealthReport() map[string]error {
+	hp := map[string]error{m.Name(): nil}
+	for _, id := range m.chainIDs {
+		services.CopyHealth(hp, m.relayers[id].HealthReport())
+	}
+	return hp
+}
+
+// singleRelayer serves the chain service calls, which are not scoped to a chain. They can't be routed when several
+// chains are configured and fail instead of silently answering for one of them.
+func (m *multiRelayer) singleRelayer(call string) (*relayer, error) {
+	if len(m.chainIDs) > 1 {
+		return nil, fmt.Errorf("%s is not scoped to a chain and can't be served for chains %v", call, m.chainIDs)
+	}
+	return m.relayers[m.chainIDs[0]], nil
+}
+
+// relayerFor picks the relayer of the chain named in the relay config. The chainID may only be omitted when a single
+// chain is configured.
+func (m *multiRelayer) relayerFor(rargs relaytypes.RelayArgs) (*relayer, error) {
+	var relayConfig RelayConfig
+	if err := json.Unmarshal(rargs.RelayConfig, &relayConfig); err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal RelayConfig: %w", err)
+	}
+	if relayConfig.ChainID == "" {
+		if len(m.chainIDs) > 1 {
+			return nil, fmt.Errorf("relay config is missing a chainID, required when serving chains %v", m.chainIDs)
+		}
+		return m.relayers[m.chainIDs[0]], nil
+	}
+	r, ok := m.relayers[relayConfig.ChainID]
+	if !ok {
+		return nil, fmt.Errorf("unknown chain %s, serving %v", relayConfig.ChainID, m.chainIDs)
+	}
+	return r, nil
+}
+
+func (m *multiRelayer) NewChainWriter(_ context.Context, _ []byte) (relaytypes.ChainWriter, error) {
+	return nil, errors.New("chain writer is not supported for starknet")
+}
+
+func (m *multiRelayer) NewContractReader(ctx context.Context, _ []byte) (relaytypes.ContractReader, error) {
+	return nil, errors.New("contract reader is not supported for starknet")
+}
+
+func (m *multiRelayer) LatestHead(ctx context.Context) (relaytypes.Head, error) {
+	r, err := m.singleRelayer("LatestHead")
+	if err != nil {
+		return relaytypes.Head{}, err
+	}
+	return r.LatestHead(ctx)
+}
+
+func (m *multiRelayer) GetChainStatus(ctx context.Context) (relaytypes.ChainStatus, error) {
+	r, err := m.singleRelayer("GetChainStatus")
+	if err != nil {
+		return relaytypes.ChainStatus{}, err
+	}
+	return r.GetChainStatus(ctx)
+}
+
+// ListNodeStatuses pages over the nodes of all chains, in config order
+func (m *multiRelayer) ListNodeStatuses(ctx context.Context, pageSize int32, pageToken string) (stats []relaytypes.NodeStatus, nextPageToken string, total int, err error) {
+	return chains.ListNodeStatuses(int(pageSize), pageToken, func(start, end int) ([]relaytypes.NodeStatus, int, error) {
+		var all []relaytypes.NodeStatus
+		for _, id := range m.chainIDs {
+			nodes, _, _, err := m.relayers[id].ListNodeStatuses(ctx, math.MaxInt32, "")
+			if err != nil && !errors.Is(err, chains.ErrOutOfRange) {
+				return nil, 0, fmt.Errorf("couldn't list nodes of chain %s: %w", id, err)
+			}
+			all = append(all, nodes...)
+		}
+		total := len(all)
+		if start >= total {
+			return []relaytypes.NodeStatus{}, total, chains.ErrOutOfRange
+		}
+		if end <= 0 || end > total {
+			end = total
+		}
+		return all[start:end], total, nil
+	})
+}
+
+func (m *multiRelayer) Transact(ctx context.Context, from, to string, amount *big.Int, balanceCheck bool) error {
+	r, err := m.singleRelayer("Transact")
+	if err != nil {
+		return err
+	}
+	return r.Transact(ctx, from, to, amount, balanceCheck)
+}
+
+// RotateKey rotates the key of a transmitter account of the chain, see [relayer.RotateKey]
+func (m *multiRelayer) RotateKey(ctx context.Context, chainID, accountAddress, currentKey, newKey string) error {
+	r, ok := m.relayers[chainID]
+	if !ok {
+		return fmt.Errorf("unknown chain %s, serving %v", chainID, m.chainIDs)
+	}
+	return r.RotateKey(ctx, accountAddress, currentKey, newKey)
+}
+
+func (m *multiRelayer) NewConfigProvider(ctx context.Context, rargs relaytypes.RelayArgs) (relaytypes.ConfigProvider, error) {
+	r, err := m.relayerFor(rargs)
+	if err != nil {
+		return nil, err
+	}
+	return r.NewConfigProvider(ctx, rargs)
+}
+
+func (m *multiRelayer) NewMedianProvider(ctx context.Context, rargs relaytypes.RelayArgs, pargs relaytypes.PluginArgs) (relaytypes.MedianProvider, error) {
+	r, err := m.relayerFor(rargs)
+	if err != nil {
+		return nil, err
+	}
+	return r.NewMedianProvider(ctx, rargs, pargs)
+}
+
+func (m *multiRelayer) NewMercuryProvider(ctx context.Context, rargs relaytypes.RelayArgs, pargs relaytypes.PluginArgs) (relaytypes.MercuryProvider, error) {
+	return nil, errors.New("mercury is not supported for starknet")
+}
+
+func (m *multiRelayer) NewLLOProvider(ctx context.Context, rargs relaytypes.RelayArgs, pargs relaytypes.PluginArgs) (relaytypes.LLOProvider, error) {
+	return nil, errors.New("data streams is not supported for starknet")
+}
+
+func (m *multiRelayer) NewFunctionsProvider(ctx context.Context, rargs relaytypes.RelayArgs, pargs relaytypes.PluginArgs) (relaytypes.FunctionsProvider, error) {
+	return nil, errors.New("functions are not supported for starknet")
+}
+
+func (m *multiRelayer) NewAutomationProvider(ctx context.Context, rargs relaytypes.RelayArgs, pargs relaytypes.PluginArgs) (relaytypes.AutomationProvider, error) {
+	return nil, errors.New("automation is not supported for starknet")
+}
+
+func (m *multiRelayer) NewPluginProvider(ctx context.Context, rargs relaytypes.RelayArgs, pargs relaytypes.PluginArgs) (relaytypes.PluginProvider, error) {
+	return nil, errors.New("plugin provider is not supported for starknet")
+}
+
+func (m *multiRelayer) NewOCR3CapabilityProvider(ctx context.Context, rargs relaytypes.RelayArgs, pargs relaytypes.PluginArgs) (relaytypes.OCR3CapabilityProvider, error) {
+	return nil, errors.New("ocr3 capability provider is not supported for starknet")
+}
+
+func (m *multiRelayer) NewCCIPCommitProvider(ctx context.Context, rargs relaytypes.RelayArgs, pargs relaytypes.PluginArgs) (relaytypes.CCIPCommitProvider, error) {
+	return nil, errors.New("ccip.commit is not supported for starknet")
+}
+
+func (m *multiRelayer) NewCCIPExecProvider(ctx context.Context, rargs relaytypes.RelayArgs, pargs relaytypes.PluginArgs) (relaytypes.CCIPExecProvider, error) {
+	return nil, errors.New("ccip.exec is not supported for starknet")
+}
@@ -0,0 +1,55 @@
+package chainlink
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	relaytypes "github.com/smartcontractkit/chainlink-common/pkg/types"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func TestMultiRelayer_RelayerFor(t *testing.T) {
+	mainnet, sepolia := &relayer{}, &relayer{}
+	m := &multiRelayer{
+		chainIDs: []string{"SN_MAIN", "SN_SEPOLIA"},
+		relayers: map[string]*relayer{"SN_MAIN": mainnet, "SN_SEPOLIA": sepolia},
+	}
+	args := func(relayConfig string) relaytypes.RelayArgs {
+		return relaytypes.RelayArgs{RelayConfig: []byte(relayConfig)}
+	}
+
+	r, err := m.relayerFor(args(`{"chainID": "SN_SEPOLIA"}`))
+	require.NoError(t, err)
+	assert.Same(t, sepolia, r)
+
+	_, err = m.relayerFor(args(`{"chainID": "SN_GOERLI"}`))
+	assert.ErrorContains(t, err, "unknown chain SN_GOERLI")
+
+	_, err = m.relayerFor(args(`{}`))
+	assert.ErrorContains(t, err, "missing a chainID")
+
+	// calls that don't name a chain aren't routed to an arbitrary one
+	_, err = m.LatestHead(tests.Context(t))
+	assert.ErrorContains(t, err, "LatestHead is not scoped to a chain")
+	_, err = m.GetChainStatus(tests.Context(t))
+	assert.ErrorContains(t, err, "GetChainStatus is not scoped to a chain")
+	err = m.Transact(tests.Context(t), "0x1", "0x2", big.NewInt(1), false)
+	assert.ErrorContains(t, err, "Transact is not scoped to a chain")
+	err = m.RotateKey(tests.Context(t), "SN_GOERLI", "0x1", "0x2", "0x3")
+	assert.ErrorContains(t, err, "unknown chain SN_GOERLI")
+
+	// a single chain doesn't need the chainID
+	m = &multiRelayer{
+		chainIDs: []string{"SN_MAIN"},
+		relayers: map[string]*relayer{"SN_MAIN": mainnet},
+	}
+	r, err = m.relayerFor(args(`{}`))
+	require.NoError(t, err)
+	assert.Same(t, mainnet, r)
+	r, err = m.singleRelayer("LatestHead")
+	require.NoError(t, err)
+	assert.Same(t, mainnet, r)
+}
@@ -0,0 +1,79 @@
+package ocr2
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/NethermindEth/juno/core/felt"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2/medianreport"
+)
+
+var (
+	// reimbursementMargin is the percentage of the computed gas the aggregator reimburses
+	reimbursementMargin = big.NewInt(115)
+	gigaJuels           = big.NewInt(1_000_000_000)
+	maxUint128          = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+)
+
+// ErrPaymentOverflow is returned for payments exceeding a u128, the aggregator reverts the transmission in that case
+var ErrPaymentOverflow = errors.New("payment overflows u128")
+
+// CalculateReimbursement is the aggregator's calculate_reimbursement: the juels paid back to the transmitter for the
+// gas of a transmit with signatureCount signatures.
+func CalculateReimbursement(juelsPerFeeCoin *big.Int, signatureCount int, gasPrice *big.Int, billing BillingDetails) (*big.Int, error) {
+	if juelsPerFeeCoin.Sign() < 0 || juelsPerFeeCoin.Cmp(maxUint128) > 0 || gasPrice.Sign() < 0 || gasPrice.Cmp(maxUint128) > 0 {
+		return nil, errors.New("juels per fee coin and gas price have to be u128")
+	}
+	if signatureCount < 0 {
+		return nil, errors.New("negative signature count")
+	}
+
+	exactGas := new(big.Int).Mul(big.NewInt(int64(signatureCount)), big.NewInt(int64(billing.GasPerSignature)))
+	exactGas.Add(exactGas, big.NewInt(int64(billing.GasBase)))
+	gas := new(big.Int).Mul(exactGas, reimbursementMargin)
+	if gas.Cmp(maxUint128) > 0 {
+		return nil, ErrPaymentOverflow
+	}
+	gas.Div(gas, big.NewInt(100))
+
+	amount := new(big.Int).Mul(gas, gasPrice)
+	if amount.Cmp(maxUint128) > 0 {
+		return nil, ErrPaymentOverflow
+	}
+	amount.Mul(amount, juelsPerFeeCoin)
+	if amount.Cmp(maxUint128) > 0 {
+		return nil, ErrPaymentOverflow
+	}
+	return amount, nil
+}
+
+// TransmissionPayment is the amount credited to the transmitter of a report: the reimbursement plus the transmission
+// payment
+func (b BillingDetails) TransmissionPayment(reimbursement *big.Int) (*big.Int, error) {
+	payment := new(big.Int).Mul(big.NewInt(int64(b.TransmissionPaymentGJuels)), gigaJuels)
+	payment.Add(payment, reimbursement)
+	if payment.Cmp(maxUint128) > 0 {
+		return nil, ErrPaymentOverflow
+	}
+	return payment, nil
+}
+
+// PredictTransmissionPayment returns the reimbursement and the total payment the transmitter of report gets
+func PredictTransmissionPayment(report medianreport.Report, signatureCount int, billing BillingDetails) (reimbursement, payment *big.Int, err error) {
+	reimbursement, err = CalculateReimbursement(report.JuelsPerFeeCoin, signatureCount, report.GasPrice, billing)
+	if err != nil {
+		return nil, nil, err
+	}
+	payment, err = billing.TransmissionPayment(reimbursement)
+	if err != nil {
+		return nil, nil, err
+	}
+	return reimbursement, payment, nil
+}
+
+// FeeInJuels converts a fee paid for a transmission, in the fee coin's smallest unit, into juels using the
+// report's juels per fee coin, to compare it with the reimbursement
+func FeeInJuels(fee *felt.Felt, juelsPerFeeCoin *big.Int) *big.Int {
+	return new(big.Int).Mul(fee.BigInt(big.NewInt(0)), juelsPerFeeCoin)
+}
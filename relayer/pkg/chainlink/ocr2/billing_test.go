@@ -0,0 +1,58 @@
+package ocr2
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2/medianreport"
+)
+
+func TestNewBillingDetails(t *testing.T) {
+	bd, err := NewBillingDetails(big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4))
+	require.NoError(t, err)
+	assert.Equal(t, BillingDetails{ObservationPaymentGJuels: 1, TransmissionPaymentGJuels: 2, GasBase: 3, GasPerSignature: 4}, bd)
+
+	_, err = NewBillingDetails(big.NewInt(1), big.NewInt(1<<32), big.NewInt(3), big.NewInt(4))
+	assert.ErrorContains(t, err, "transmission payment '4294967296' does not fit into uint32")
+}
+
+func TestCalculateReimbursement(t *testing.T) {
+	billing := BillingDetails{TransmissionPaymentGJuels: 5, GasBase: 14951, GasPerSignature: 13}
+
+	// (14951 + 2 * 13) * 115 / 100 = 17223 gas
+	reimbursement, err := CalculateReimbursement(big.NewInt(3), 2, big.NewInt(7), billing)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(17223*7*3), reimbursement)
+
+	payment, err := billing.TransmissionPayment(reimbursement)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(17223*7*3+5_000_000_000), payment)
+
+	reimbursement, payment, err = PredictTransmissionPayment(medianreport.Report{JuelsPerFeeCoin: big.NewInt(3), GasPrice: big.NewInt(7)}, 2, billing)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(17223*7*3), reimbursement)
+	assert.Equal(t, big.NewInt(17223*7*3+5_000_000_000), payment)
+
+	assert.Equal(t, big.NewInt(1000*3), FeeInJuels(new(felt.Felt).SetUint64(1000), big.NewInt(3)))
+
+	t.Run("overflows", func(t *testing.T) {
+		_, err := CalculateReimbursement(maxUint128, 2, big.NewInt(7), billing)
+		assert.ErrorIs(t, err, ErrPaymentOverflow)
+		_, err = CalculateReimbursement(big.NewInt(1), 2, maxUint128, billing)
+		assert.ErrorIs(t, err, ErrPaymentOverflow)
+		_, err = billing.TransmissionPayment(maxUint128)
+		assert.ErrorIs(t, err, ErrPaymentOverflow)
+
+		_, err = CalculateReimbursement(new(big.Int).Add(maxUint128, big.NewInt(1)), 2, big.NewInt(7), billing)
+		assert.ErrorContains(t, err, "have to be u128")
+
+		// no gas used, nothing to reimburse
+		reimbursement, err := CalculateReimbursement(maxUint128, 0, maxUint128, BillingDetails{})
+		require.NoError(t, err)
+		assert.Zero(t, reimbursement.Sign())
+	})
+}
@@ -23,9 +23,12 @@ type OCR2Reader interface { //nolint:revive
 	LatestTransmissionDetails(context.Context, *felt.Felt) (TransmissionDetails, error)
 	LatestRoundData(context.Context, *felt.Felt) (RoundData, error)
 	LinkAvailableForPayment(context.Context, *felt.Felt) (*big.Int, error)
+	OwedPayment(context.Context, *felt.Felt, *felt.Felt) (*big.Int, error)
 	ConfigFromEventAt(context.Context, *felt.Felt, uint64) (ContractConfig, error)
-	NewTransmissionsFromEventsAt(context.Context, *felt.Felt, uint64) ([]NewTransmissionEvent, error)
+	ConfigHistory(context.Context, string, *felt.Felt) ([]ConfigHistoryEntry, error)
+	NewTransmissionsFromEventsAt(context.Context, *felt.Felt, uint64, bool) ([]NewTransmissionEvent, error)
 	BillingDetails(context.Context, *felt.Felt) (BillingDetails, error)
+	BillingAccessController(context.Context, *felt.Felt) (*felt.Felt, error)
 
 	BaseReader() starknet.Reader
 }
@@ -64,10 +67,12 @@ func (c *Client) BillingDetails(ctx context.Context, address *felt.Felt) (bd Bil
 		return bd, errors.New("unexpected result length")
 	}
 
-	observationPayment := res[0].BigInt(big.NewInt(0))
-	transmissionPayment := res[1].BigInt(big.NewInt(0))
-
-	bd, err = NewBillingDetails(observationPayment, transmissionPayment)
+	bd, err = NewBillingDetails(
+		res[0].BigInt(big.NewInt(0)),
+		res[1].BigInt(big.NewInt(0)),
+		res[2].BigInt(big.NewInt(0)),
+		res[3].BigInt(big.NewInt(0)),
+	)
 	if err != nil {
 		return bd, fmt.Errorf("couldn't initialize billing details: %w", err)
 	}
@@ -75,6 +80,16 @@ func (c *Client) BillingDetails(ctx context.Context, address *felt.Felt) (bd Bil
 	return
 }
 
+// BillingAccessController returns the access controller allowed to change the billing config. It has no getter, so it
+// is read from the contract storage in a separate request.
+func (c *Client) BillingAccessController(ctx context.Context, address *felt.Felt) (*felt.Felt, error) {
+	controller, err := c.r.StorageAt(ctx, address, "_billing_access_controller")
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the billing access controller: %w", err)
+	}
+	return controller, nil
+}
+
 func (c *Client) LatestConfigDetails(ctx context.Context, address *felt.Felt) (ccd ContractConfigDetails, err error) {
 	ops := starknet.CallOps{
 		ContractAddress: address,
@@ -86,6 +101,11 @@ func (c *Client) LatestConfigDetails(ctx context.Context, address *felt.Felt) (c
 		return ccd, fmt.Errorf("couldn't call the contract: %w", err)
 	}
 
+	return parseConfigDetails(res)
+}
+
+// parseConfigDetails decodes the result of latest_config_details
+func parseConfigDetails(res []*felt.Felt) (ccd ContractConfigDetails, err error) {
 	// [0] - config count, [1] - block number, [2] - config digest
 	if len(res) != 3 {
 		return ccd, errors.New("unexpected result length")
@@ -113,6 +133,11 @@ func (c *Client) LatestTransmissionDetails(ctx context.Context, address *felt.Fe
 		return td, fmt.Errorf("couldn't call the contract: %w", err)
 	}
 
+	return parseTransmissionDetails(res)
+}
+
+// parseTransmissionDetails decodes the result of latest_transmission_details
+func parseTransmissionDetails(res []*felt.Felt) (td TransmissionDetails, err error) {
 	// [0] - config digest, [1] - epoch and round, [2] - latest answer, [3] - latest timestamp
 	if len(res) != 4 {
 		return td, errors.New("unexpected result length")
@@ -184,6 +209,23 @@ func (c *Client) LinkAvailableForPayment(ctx context.Context, address *felt.Felt
 	return ans, nil
 }
 
+// OwedPayment returns the juels owed to a transmitter, which are paid out to its payee by withdraw_payment
+func (c *Client) OwedPayment(ctx context.Context, address *felt.Felt, transmitter *felt.Felt) (*big.Int, error) {
+	results, err := c.r.CallContract(ctx, starknet.CallOps{
+		ContractAddress: address,
+		Selector:        starknetutils.GetSelectorFromNameFelt("owed_payment"),
+		Calldata:        []*felt.Felt{transmitter},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call the contract with selector 'owed_payment': %w", err)
+	}
+	if l := len(results); l != 1 {
+		return nil, fmt.Errorf("unexpected data from selector 'owed_payment': need 1 result but got %d", l)
+	}
+
+	return results[0].BigInt(big.NewInt(0)), nil
+}
+
 func (c *Client) collectAllEvents(ctx context.Context, block starknetrpc.BlockID, address *felt.Felt, eventKey *felt.Felt, pageSize int) (events []starknetrpc.EmittedEvent, err error) {
 	input := starknetrpc.EventsInput{
 		EventFilter: starknetrpc.EventFilter{
@@ -267,8 +309,66 @@ func (c *Client) ConfigFromEventAt(ctx context.Context, address *felt.Felt, bloc
 	}, nil
 }
 
+// ConfigHistory walks back through every ConfigSet event emitted by the contract, following the previous config block
+// recorded by the aggregator. Entries are returned newest first. The digest of every config is recomputed for the given
+// chain ID and compared to the digest emitted on-chain.
+func (c *Client) ConfigHistory(ctx context.Context, chainID string, address *felt.Felt) (history []ConfigHistoryEntry, err error) {
+	details, err := c.LatestConfigDetails(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch latest config details: %w", err)
+	}
+
+	digester := NewOffchainConfigDigester(chainID, address.String())
+
+	// the aggregator records a previous config block of 0 for the first config
+	blockNum := details.Block
+	for blockNum != 0 {
+		events, err := c.fetchEventsFromBlock(ctx, address, "ConfigSet", blockNum)
+		if err != nil {
+			return history, fmt.Errorf("failed to fetch config_set events: %w", err)
+		}
+
+		// set_config may be called several times in a single block, walk the events newest first
+		var prevBlock uint64
+		for i := len(events) - 1; i >= 0; i-- {
+			config, err := ParseConfigSetEvent(events[i])
+			if err != nil {
+				return history, fmt.Errorf("couldn't parse config event in block %d: %w", blockNum, err)
+			}
+			prevBlock, err = ParseConfigSetPreviousBlock(events[i])
+			if err != nil {
+				return history, fmt.Errorf("couldn't parse config event in block %d: %w", blockNum, err)
+			}
+
+			digest, err := digester.ConfigDigest(ctx, config)
+			if err != nil {
+				return history, fmt.Errorf("couldn't compute digest for config %d: %w", config.ConfigCount, err)
+			}
+			if digest != config.ConfigDigest {
+				return history, fmt.Errorf("config digest mismatch for config %d in block %d: emitted %s, computed %s", config.ConfigCount, blockNum, config.ConfigDigest, digest)
+			}
+
+			history = append(history, ConfigHistoryEntry{
+				ContractConfig: ContractConfig{
+					Config:      config,
+					ConfigBlock: blockNum,
+				},
+				PreviousConfigBlock: prevBlock,
+			})
+		}
+
+		if prevBlock >= blockNum {
+			return history, fmt.Errorf("previous config block %d is not before block %d", prevBlock, blockNum)
+		}
+		blockNum = prevBlock
+	}
+
+	return history, nil
+}
+
 // NewTransmissionsFromEventsAt finds events of type new_transmission emitted by the contract address in a given block number.
-func (c *Client) NewTransmissionsFromEventsAt(ctx context.Context, address *felt.Felt, blockNum uint64) (events []NewTransmissionEvent, err error) {
+// signed is set for aggregators storing i128 answers.
+func (c *Client) NewTransmissionsFromEventsAt(ctx context.Context, address *felt.Felt, blockNum uint64, signed bool) (events []NewTransmissionEvent, err error) {
 	rawEvents, err := c.fetchEventsFromBlock(ctx, address, "NewTransmission", blockNum)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch new_transmission events: %w", err)
@@ -276,9 +376,13 @@ func (c *Client) NewTransmissionsFromEventsAt(ctx context.Context, address *felt
 	if len(rawEvents) == 0 {
 		return nil, fmt.Errorf("expected to find at least one new_transmission event in block %d for address %s but found %d", blockNum, address, len(rawEvents))
 	}
+	parse := ParseNewTransmissionEvent
+	if signed {
+		parse = ParseSignedNewTransmissionEvent
+	}
 	events = []NewTransmissionEvent{}
 	for _, rawEvent := range rawEvents {
-		event, err := ParseNewTransmissionEvent(rawEvent)
+		event, err := parse(rawEvent)
 		if err != nil {
 			return nil, fmt.Errorf("couldn't parse new_transmission event: %w", err)
 		}
@@ -5,20 +5,28 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/NethermindEth/juno/core/felt"
 	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
 	starknetutils "github.com/NethermindEth/starknet.go/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
 
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2/medianreport"
 	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet/mocks"
 )
 
 const blockOutput = `{"result": {"events": [ {"from_address": "0xd43963a4e875a361f5d164b2e70953598eb4f45fde86924082d51b4d78e489", "keys": ["0x9a144bf4a6a8fd083c93211e163e59221578efcc86b93f8c97c620e7b9608a", "0x0", "0x4b791b801cf0d7b6a2f9e59daf15ec2dd7d9cdc3bc5e037bada9c86e4821c"], "data": ["0x1", "0x4", "0x4cc1bfa99e282e434aef2815ca17337a923cd2c61cf0c7de5b326d7a8603730", "0x4cc1bfa99e282e434aef2815ca17337a923cd2c61cf0c7de5b326d7a8603734", "0x4cc1bfa99e282e434aef2815ca17337a923cd2c61cf0c7de5b326d7a8603731", "0x4cc1bfa99e282e434aef2815ca17337a923cd2c61cf0c7de5b326d7a8603735", "0x4cc1bfa99e282e434aef2815ca17337a923cd2c61cf0c7de5b326d7a8603732", "0x4cc1bfa99e282e434aef2815ca17337a923cd2c61cf0c7de5b326d7a8603736", "0x4cc1bfa99e282e434aef2815ca17337a923cd2c61cf0c7de5b326d7a8603733", "0x4cc1bfa99e282e434aef2815ca17337a923cd2c61cf0c7de5b326d7a8603737", "0x1", "0x3", "0x1", "0x0", "0xf4240", "0x2", "0x15", "0x263", "0x880a0d9e61d1080d88ee16f1880bcc1960b2080cab5ee01288090dfc04a30", "0x53a0201024220af400004fa5d02cd5170b5261032e71f2847ead36159cf8d", "0xee68affc3c8520904220af400004fa5d02cd5170b5261032e71f2847ead361", "0x59cf8dee68affc3c8520914220af400004fa5d02cd5170b5261032e71f2847", "0xead36159cf8dee68affc3c8520924220af400004fa5d02cd5170b5261032e7", "0x1f2847ead36159cf8dee68affc3c8520934a42307830346363316266613939", "0x65323832653433346165663238313563613137333337613932336364326336", "0x31636630633764653562333236643761383630333733304a42307830346363", "0x31626661393965323832653433346165663238313563613137333337613932", "0x33636432633631636630633764653562333236643761383630333733314a42", "0x30783034636331626661393965323832653433346165663238313563613137", "0x33333761393233636432633631636630633764653562333236643761383630", "0x333733324a4230783034636331626661393965323832653433346165663238", "0x31356361313733333761393233636432633631636630633764653562333236", "0x643761383630333733335200608094ebdc03688084af5f708084af5f788084", "0xaf5f82018c010a202ac49e648a1f84da5a143eeab68c8402c65a1567e63971", "0x7f5732d5e6310c2c761220a6c1ae85186dc981dc61cd14d7511ee5ab70258a", "0x10ac4e03e4d4991761b2c0a61a1090696dc7afed7f61a26887e78e683a1c1a", "0x10a29e5fa535f2edea7afa9acb4fd349b31a10d1b88713982955d79fa0e422", "0x685a748b1a10a07e0118cc38a71d2a9d60bf52938b4a"]}]}}`
@@ -470,9 +478,13 @@ func TestOCR2Client(t *testing.T) {
 				case starknetutils.GetSelectorFromNameFelt("link_available_for_payment").String():
 					// latest transmission details response
 					out = []byte(`{"result":["0x0","0x0"]}`)
+				case starknetutils.GetSelectorFromNameFelt("owed_payment").String():
+					out = []byte(`{"result":["0x2a"]}`)
 				default:
 					require.False(t, true, "unsupported contract method %s", reqdata.Selector)
 				}
+			case "starknet_getStorageAt":
+				out = []byte(`{"result":"0x5"}`)
 			case "starknet_getEvents":
 				eventsReq := starknetrpc.EventsInput{}
 				require.NoError(t, json.Unmarshal(call.Params[0], &eventsReq))
@@ -514,6 +526,10 @@ func TestOCR2Client(t *testing.T) {
 		billing, err := client.BillingDetails(context.Background(), contractAddress)
 		require.NoError(t, err)
 		fmt.Printf("%+v\n", billing)
+
+		controller, err := client.BillingAccessController(context.Background(), contractAddress)
+		require.NoError(t, err)
+		assert.Equal(t, new(felt.Felt).SetUint64(5), controller)
 	})
 
 	t.Run("get latest config details", func(t *testing.T) {
@@ -533,7 +549,7 @@ func TestOCR2Client(t *testing.T) {
 	})
 
 	t.Run("get new transmission event", func(t *testing.T) {
-		events, err := client.NewTransmissionsFromEventsAt(context.Background(), contractAddress, 123)
+		events, err := client.NewTransmissionsFromEventsAt(context.Background(), contractAddress, 123, false)
 		require.NoError(t, err)
 		assert.Len(t, events, 15)
 	})
@@ -550,9 +566,125 @@ func TestOCR2Client(t *testing.T) {
 		fmt.Printf("%+v\n", available)
 	})
 
+	t.Run("get owed payment", func(t *testing.T) {
+		owed, err := client.OwedPayment(context.Background(), contractAddress, new(felt.Felt).SetUint64(1))
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(42), owed)
+	})
+
 	t.Run("get latest transmission", func(t *testing.T) {
 		round, err := client.LatestRoundData(context.Background(), contractAddress)
 		assert.NoError(t, err)
 		fmt.Printf("%+v\n", round)
 	})
 }
+
+func TestOCR2Client_ConfigHistory(t *testing.T) {
+	chainID := "SN_SEPOLIA"
+	lggr := logger.Test(t)
+	ctx := tests.Context(t)
+
+	contractAddress, err := starknetutils.HexToFelt(ocr2ContractAddress)
+	require.NoError(t, err)
+	digester := NewOffchainConfigDigester(chainID, contractAddress.String())
+
+	newConfig := func(count uint64, offchainConfig []byte) types.ContractConfig {
+		onchainConfig, err := medianreport.OnchainConfigCodec{}.EncodeFromFelt(big.NewInt(medianreport.OnchainConfigVersion), big.NewInt(0), big.NewInt(1000))
+		require.NoError(t, err)
+		cfg := types.ContractConfig{
+			ConfigCount:           count,
+			F:                     1,
+			OnchainConfig:         onchainConfig,
+			OffchainConfigVersion: 2,
+			OffchainConfig:        offchainConfig,
+		}
+		for i := uint64(1); i <= 4; i++ {
+			signer := make([]byte, 32)
+			signer[31] = byte(count*10 + i)
+			cfg.Signers = append(cfg.Signers, signer)
+			cfg.Transmitters = append(cfg.Transmitters, types.Account(fmt.Sprintf("0x%x", 100+i)))
+		}
+		cfg.ConfigDigest, err = digester.ConfigDigest(ctx, cfg)
+		require.NoError(t, err)
+		return cfg
+	}
+
+	configs := []types.ContractConfig{
+		newConfig(1, []byte{1}),
+		newConfig(2, []byte{2}),
+		newConfig(3, []byte{3}),
+	}
+	blocks := map[uint64][]starknetrpc.EmittedEvent{
+		10: {configSetEvent(t, configs[0], 0)},
+		// two configs set within the same block
+		20: {configSetEvent(t, configs[1], 10), configSetEvent(t, configs[2], 20)},
+	}
+
+	reader := mocks.NewReader(t)
+	reader.On("CallContract", mock.Anything, mock.Anything).Return([]*felt.Felt{
+		new(felt.Felt).SetUint64(3),
+		new(felt.Felt).SetUint64(20),
+		new(felt.Felt).SetBytes(configs[2].ConfigDigest[:]),
+	}, nil)
+	reader.On("LatestBlockHeight", mock.Anything).Return(uint64(777), nil)
+	reader.On("Events", mock.Anything, mock.Anything).Return(func(_ context.Context, input starknetrpc.EventsInput) (*starknetrpc.EventChunk, error) {
+		return &starknetrpc.EventChunk{Events: blocks[*input.FromBlock.Number]}, nil
+	})
+
+	client, err := NewClient(reader, lggr)
+	require.NoError(t, err)
+
+	t.Run("walks back through all configs", func(t *testing.T) {
+		history, err := client.ConfigHistory(ctx, chainID, contractAddress)
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+
+		assert.Equal(t, configs[2], history[0].Config)
+		assert.Equal(t, uint64(20), history[0].ConfigBlock)
+		assert.Equal(t, uint64(20), history[0].PreviousConfigBlock)
+
+		assert.Equal(t, configs[1], history[1].Config)
+		assert.Equal(t, uint64(20), history[1].ConfigBlock)
+		assert.Equal(t, uint64(10), history[1].PreviousConfigBlock)
+
+		assert.Equal(t, configs[0], history[2].Config)
+		assert.Equal(t, uint64(10), history[2].ConfigBlock)
+		assert.Equal(t, uint64(0), history[2].PreviousConfigBlock)
+	})
+
+	t.Run("digest mismatch", func(t *testing.T) {
+		_, err := client.ConfigHistory(ctx, "SN_MAIN", contractAddress)
+		require.ErrorContains(t, err, "config digest mismatch")
+	})
+}
+
+func configSetEvent(t *testing.T, cfg types.ContractConfig, prevBlock uint64) starknetrpc.EmittedEvent {
+	onchainConfig, err := medianreport.OnchainConfigCodec{}.DecodeToFelts(cfg.OnchainConfig)
+	require.NoError(t, err)
+	offchainConfig := starknet.EncodeFelts(cfg.OffchainConfig)
+
+	data := []*big.Int{
+		new(big.Int).SetUint64(cfg.ConfigCount),
+		big.NewInt(int64(len(cfg.Signers))),
+	}
+	for i := range cfg.Signers {
+		transmitter, err := starknetutils.HexToFelt(string(cfg.Transmitters[i]))
+		require.NoError(t, err)
+		data = append(data, new(big.Int).SetBytes(cfg.Signers[i]), transmitter.BigInt(big.NewInt(0)))
+	}
+	data = append(data, big.NewInt(int64(cfg.F)), big.NewInt(int64(len(onchainConfig))))
+	data = append(data, onchainConfig...)
+	data = append(data, new(big.Int).SetUint64(cfg.OffchainConfigVersion), big.NewInt(int64(len(offchainConfig))))
+	data = append(data, offchainConfig...)
+
+	return starknetrpc.EmittedEvent{
+		Event: starknetrpc.Event{
+			Keys: []*felt.Felt{
+				starknetutils.GetSelectorFromNameFelt("ConfigSet"),
+				new(felt.Felt).SetUint64(prevBlock),
+				new(felt.Felt).SetBytes(cfg.ConfigDigest[:]),
+			},
+			Data: starknetutils.Map(data, starknetutils.BigIntToFelt),
+		},
+	}
+}
@@ -16,7 +16,7 @@ import (
 type Tracker interface {
 	Start() error
 	Close() error
-	poll()
+	poll(refreshed <-chan struct{})
 }
 
 var _ Tracker = (*contractCache)(nil)
@@ -28,6 +28,9 @@ type contractCache struct {
 	ccLock          sync.RWMutex
 	ccLastCheckedAt time.Time
 
+	notify chan struct{}
+	// refreshed returns the channel closed on the next refresh of the shared state the reader is served from
+	refreshed  func() <-chan struct{}
 	stop, done chan struct{}
 
 	reader Reader
@@ -35,23 +38,33 @@ type contractCache struct {
 	lggr   logger.Logger
 }
 
-func NewContractCache(cfg Config, reader Reader, lggr logger.Logger) *contractCache {
+func NewContractCache(cfg Config, reader Reader, refreshed func() <-chan struct{}, lggr logger.Logger) *contractCache {
 	return &contractCache{
-		cfg:    cfg,
-		reader: reader,
-		lggr:   lggr,
-		stop:   make(chan struct{}),
-		done:   make(chan struct{}),
+		cfg:       cfg,
+		reader:    reader,
+		refreshed: refreshed,
+		lggr:      lggr,
+		notify:    make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
 	}
 }
 
+// updateConfig copies the config details from the shared state the reader is served from, the full config is only
+// read from the chain when the digest changed.
 func (c *contractCache) updateConfig(ctx context.Context) error {
+	blockHeight, err := c.reader.LatestBlockHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch latest block height: %w", err)
+	}
+
 	configBlock, configDigest, err := c.reader.LatestConfigDetails(ctx)
 	if err != nil {
 		return fmt.Errorf("couldn't fetch latest config details: %w", err)
 	}
 
 	c.ccLock.RLock()
+	initialized := !c.ccLastCheckedAt.IsZero()
 	isSame := c.contractConfig.ConfigBlock == configBlock && c.contractConfig.Config.ConfigDigest == configDigest
 	c.ccLock.RUnlock()
 
@@ -63,22 +76,24 @@ func (c *contractCache) updateConfig(ctx context.Context) error {
 		}
 	}
 
-	blockHeight, err := c.reader.LatestBlockHeight(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to fetch latest block height: %w", err)
-	}
-
 	c.lggr.Debugw("contract cache update", "blockHeight", blockHeight, "configBlock", configBlock, "configDigest", configDigest)
 
 	c.ccLock.Lock()
 	defer c.ccLock.Unlock()
 	c.ccLastCheckedAt = time.Now()
-	c.blockHeight = blockHeight
+	c.blockHeight = max(c.blockHeight, blockHeight)
 	if !isSame {
 		c.contractConfig = ContractConfig{
 			Config:      newConfig,
 			ConfigBlock: configBlock,
 		}
+		// let libocr pick up the new config without waiting for its own poll
+		if initialized {
+			select {
+			case c.notify <- struct{}{}:
+			default:
+			}
+		}
 	}
 
 	return nil
@@ -87,10 +102,12 @@ func (c *contractCache) updateConfig(ctx context.Context) error {
 func (c *contractCache) Start() error {
 	ctx, cancel := utils.ContextFromChan(c.stop)
 	defer cancel()
+	// subscribe before the update so a refresh during it isn't missed
+	refreshed := c.refreshed()
 	if err := c.updateConfig(ctx); err != nil {
 		c.lggr.Warnf("Failed to populate initial config: %v", err)
 	}
-	go c.poll()
+	go c.poll(refreshed)
 	return nil
 }
 
@@ -99,28 +116,27 @@ func (c *contractCache) Close() error {
 	return nil
 }
 
-func (c *contractCache) poll() {
+func (c *contractCache) poll(refreshed <-chan struct{}) {
 	defer close(c.done)
-	tick := time.After(0)
 	for {
 		select {
 		case <-c.stop:
 			return
-		case <-tick:
+		case <-refreshed:
+			refreshed = c.refreshed()
 			ctx, cancel := utils.ContextFromChan(c.stop)
 
 			if err := c.updateConfig(ctx); err != nil {
 				c.lggr.Errorf("Failed to update config: %v", err)
 			}
 			cancel()
-
-			tick = time.After(utils.WithJitter(c.cfg.OCR2CachePollPeriod()))
 		}
 	}
 }
 
+// Notify signals when a new config was cached
 func (c *contractCache) Notify() <-chan struct{} {
-	return nil
+	return c.notify
 }
 
 func (c *contractCache) LatestConfigDetails(ctx context.Context) (changedInBlock uint64, configDigest types.ConfigDigest, err error) {
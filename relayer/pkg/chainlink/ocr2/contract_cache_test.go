@@ -0,0 +1,167 @@
+package ocr2
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+type testCacheConfig struct{}
+
+func (testCacheConfig) OCR2CachePollPeriod() time.Duration { return time.Hour }
+func (testCacheConfig) OCR2CacheTTL() time.Duration        { return time.Hour }
+
+// fakeReader is a contract whose state is changed by the test, counting the full config reads done by the caches
+type fakeReader struct {
+	Reader
+
+	mu          sync.Mutex
+	blockHeight uint64
+	config      types.ContractConfig
+	configBlock uint64
+	answer      *big.Int
+	configReads int
+}
+
+func (r *fakeReader) LatestBlockHeight(context.Context) (uint64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.blockHeight, nil
+}
+
+func (r *fakeReader) LatestConfigDetails(context.Context) (uint64, types.ConfigDigest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.configBlock, r.config.ConfigDigest, nil
+}
+
+func (r *fakeReader) LatestConfig(context.Context, uint64) (types.ContractConfig, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.configReads++
+	return r.config, nil
+}
+
+func (r *fakeReader) LatestTransmissionDetails(context.Context) (types.ConfigDigest, uint32, uint8, *big.Int, time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.config.ConfigDigest, 1, 1, r.answer, time.Unix(1, 0), nil
+}
+
+// advance mines a block, optionally changing the contract state
+func (r *fakeReader) advance(update func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.blockHeight++
+	if update != nil {
+		update()
+	}
+}
+
+func newFakeReader() *fakeReader {
+	r := &fakeReader{blockHeight: 10, configBlock: 5, answer: big.NewInt(1)}
+	r.config.ConfigDigest[31] = 1
+	return r
+}
+
+func TestContractCache_UpdateConfig(t *testing.T) {
+	ctx := tests.Context(t)
+	reader := newFakeReader()
+	cache := NewContractCache(testCacheConfig{}, reader, nil, logger.Test(t))
+
+	require.NoError(t, cache.updateConfig(ctx))
+	assert.Equal(t, 1, reader.configReads)
+	_, digest, err := cache.LatestConfigDetails(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, reader.config.ConfigDigest, digest)
+	assert.Empty(t, cache.Notify(), "initial config isn't signalled")
+
+	// new blocks without config changes: the config isn't read again
+	reader.advance(nil)
+	reader.advance(nil)
+	require.NoError(t, cache.updateConfig(ctx))
+	assert.Equal(t, 1, reader.configReads)
+	assert.Empty(t, cache.Notify())
+	height, err := cache.LatestBlockHeight(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(12), height)
+
+	// new config
+	reader.advance(func() {
+		reader.config.ConfigDigest[31] = 2
+		reader.configBlock = reader.blockHeight
+	})
+	require.NoError(t, cache.updateConfig(ctx))
+	assert.Equal(t, 2, reader.configReads)
+	select {
+	case <-cache.Notify():
+	default:
+		t.Fatal("expected a notification for the new config")
+	}
+	changedInBlock, digest, err := cache.LatestConfigDetails(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(13), changedInBlock)
+	assert.Equal(t, reader.config.ConfigDigest, digest)
+
+	// a node lagging behind doesn't move the block height back
+	reader.blockHeight = 11
+	require.NoError(t, cache.updateConfig(ctx))
+	height, err = cache.LatestBlockHeight(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(13), height)
+}
+
+func TestTransmissionsCache_UpdateTransmission(t *testing.T) {
+	ctx := tests.Context(t)
+	reader := newFakeReader()
+	cache := NewTransmissionsCache(testCacheConfig{}, reader, nil, logger.Test(t))
+
+	require.NoError(t, cache.updateTransmission(ctx))
+	reader.advance(func() { reader.answer = big.NewInt(42) })
+	require.NoError(t, cache.updateTransmission(ctx))
+	_, _, _, answer, _, err := cache.LatestTransmissionDetails(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(42), answer)
+}
+
+func TestContractCache_UpdatesOnRefresh(t *testing.T) {
+	reader := newFakeReader()
+	var lock sync.Mutex
+	refreshed := make(chan struct{})
+	cache := NewContractCache(testCacheConfig{}, reader, func() <-chan struct{} {
+		lock.Lock()
+		defer lock.Unlock()
+		return refreshed
+	}, logger.Test(t))
+	require.NoError(t, cache.Start())
+	t.Cleanup(func() { require.NoError(t, cache.Close()) })
+
+	reader.advance(func() {
+		reader.config.ConfigDigest[31] = 2
+		reader.configBlock = reader.blockHeight
+	})
+	// nothing is read until the shared state is refreshed
+	reader.mu.Lock()
+	assert.Equal(t, 1, reader.configReads)
+	reader.mu.Unlock()
+
+	lock.Lock()
+	close(refreshed)
+	refreshed = make(chan struct{})
+	lock.Unlock()
+	select {
+	case <-cache.Notify():
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a notification for the new config")
+	}
+}
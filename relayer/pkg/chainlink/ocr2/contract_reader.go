@@ -13,6 +13,8 @@ import (
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2/medianreport"
 )
 
 type Reader interface {
@@ -25,19 +27,22 @@ var _ Reader = (*contractReader)(nil)
 type contractReader struct {
 	address *felt.Felt
 	reader  OCR2Reader
-	lggr    logger.Logger
+	// signedAnswers is set for aggregators storing i128 answers as two's complement u128s
+	signedAnswers bool
+	lggr          logger.Logger
 }
 
-func NewContractReader(address string, reader OCR2Reader, lggr logger.Logger) Reader {
+func NewContractReader(address string, reader OCR2Reader, signedAnswers bool, lggr logger.Logger) Reader {
 	felt, err := starknetutils.HexToFelt(address)
 	if err != nil {
 		panic("invalid felt value")
 	}
 
 	return &contractReader{
-		address: felt, // TODO: propagate type everywhere
-		reader:  reader,
-		lggr:    lggr,
+		address:       felt, // TODO: propagate type everywhere
+		reader:        reader,
+		signedAnswers: signedAnswers,
+		lggr:          lggr,
 	}
 }
 
@@ -90,6 +95,7 @@ func (c *contractReader) LatestTransmissionDetails(
 	transmissionDetails, err := c.reader.LatestTransmissionDetails(ctx, c.address)
 	if err != nil {
 		err = fmt.Errorf("couldn't get transmission details: %w", err)
+		return
 	}
 
 	configDigest = transmissionDetails.Digest
@@ -98,9 +104,23 @@ func (c *contractReader) LatestTransmissionDetails(
 	latestAnswer = transmissionDetails.LatestAnswer
 	latestTimestamp = transmissionDetails.LatestTimestamp
 
+	latestAnswer, err = decodeAnswer(latestAnswer, c.signedAnswers)
+
 	return
 }
 
+// decodeAnswer converts the answers of aggregators storing i128 answers from their two's complement form
+func decodeAnswer(answer *big.Int, signed bool) (*big.Int, error) {
+	if !signed {
+		return answer, nil
+	}
+	decoded, err := medianreport.DecodeI128(answer)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decode signed answer: %w", err)
+	}
+	return decoded, nil
+}
+
 // round will never be requested on Starknet so we return 0 values
 func (c *contractReader) LatestRoundRequested(
 	ctx context.Context,
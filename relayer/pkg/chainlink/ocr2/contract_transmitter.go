@@ -3,12 +3,17 @@ package ocr2
 import (
 	"context"
 	"encoding/hex"
-	"errors"
 	"fmt"
 
 	"github.com/NethermindEth/juno/core/felt"
 	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
 	starknetutils "github.com/NethermindEth/starknet.go/utils"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 
 	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2/medianreport"
 	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/txm"
@@ -18,33 +23,49 @@ import (
 
 var _ types.ContractTransmitter = (*contractTransmitter)(nil)
 
+var tracer = otel.Tracer("github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2")
+
+// SequencerStatus reports the status of the L2 sequencer, transmissions are paused while it is down
+type SequencerStatus interface {
+	SequencerDown() bool
+}
+
 type contractTransmitter struct {
-	reader *transmissionsCache
+	reader  *transmissionsCache
+	configs types.ContractConfigTracker
 
 	contractAddress *felt.Felt
-	senderAddress   *felt.Felt // account.publicKey
-	accountAddress  *felt.Felt
+	accounts        *transmitterPool
+	sequencer       SequencerStatus
 
-	txm txm.TxManager
+	txm  txm.TxManager
+	lggr logger.Logger
 }
 
+// NewContractTransmitter creates a transmitter for the aggregator. The latest config from configs is used to verify
+// the report signatures before they are sent on-chain, and to only send from accounts registered as transmitters.
+// The first of accounts is the one reported to libocr, the others are picked from according to selection.
+// Reports are dropped while sequencer reports the sequencer as down, a nil sequencer disables the check.
 func NewContractTransmitter(
 	reader *transmissionsCache,
+	configs types.ContractConfigTracker,
 	contractAddress string,
-	senderAddress string,
-	accountAddress string,
+	accounts []TransmitterAccount,
+	selection TransmitterSelection,
+	sequencer SequencerStatus,
 	txm txm.TxManager,
+	lggr logger.Logger,
 ) *contractTransmitter {
 	contractAddr, _ := starknetutils.HexToFelt(contractAddress)
-	senderAddr, _ := starknetutils.HexToFelt(senderAddress)
-	accountAddr, _ := starknetutils.HexToFelt(accountAddress)
 
 	return &contractTransmitter{
 		reader:          reader,
+		configs:         configs,
 		contractAddress: contractAddr,
-		senderAddress:   senderAddr,
-		accountAddress:  accountAddr,
+		accounts:        newTransmitterPool(accounts, selection, txm),
+		sequencer:       sequencer,
 		txm:             txm,
+		lggr:            lggr,
 	}
 }
 
@@ -53,7 +74,32 @@ func (c *contractTransmitter) Transmit(
 	reportCtx types.ReportContext,
 	report types.Report,
 	sigs []types.AttributedOnchainSignature,
-) error {
+) (err error) {
+	// the TXM traces the broadcast of the transmission as a child of this span
+	ctx, span := tracer.Start(ctx, "ocr2.Transmit", trace.WithAttributes(
+		attribute.String("starknet.contract_address", c.contractAddress.String()),
+		attribute.Int64("ocr2.epoch", int64(reportCtx.Epoch)),
+		attribute.Int64("ocr2.round", int64(reportCtx.Round)),
+	))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if c.sequencer != nil && c.sequencer.SequencerDown() {
+		c.lggr.Warnw("Sequencer is down, skipping report", "configDigest", reportCtx.ConfigDigest, "epoch", reportCtx.Epoch, "round", reportCtx.Round)
+		promTransmissionsSkipped.WithLabelValues(c.contractAddress.String(), skipReasonSequencerDown).Inc()
+		return nil
+	}
+	if reason := c.staleReason(ctx, reportCtx); reason != "" {
+		c.lggr.Infow("Skipping stale report", "reason", reason, "configDigest", reportCtx.ConfigDigest, "epoch", reportCtx.Epoch, "round", reportCtx.Round)
+		promTransmissionsSkipped.WithLabelValues(c.contractAddress.String(), reason).Inc()
+		return nil
+	}
+
 	// flat array of arguments
 	// convert everything to hex string -> caigo internally converts into big.int
 	var transmitPayload []string
@@ -77,16 +123,21 @@ func (c *contractTransmitter) Transmit(
 		transmitPayload = append(transmitPayload, "0x"+hexStr)
 	}
 
-	transmitPayload = append(transmitPayload, "0x"+fmt.Sprintf("%x", len(sigs))) // signatures_len
+	var signatures []TransmitSignature
 	for _, sig := range sigs {
-		// signature: 32 byte public key + 32 byte R + 32 byte S
-		signature := sig.Signature
-		if len(signature) != 32+32+32 {
-			return errors.New("invalid length of the signature")
+		signature, err := ParseOnchainSignature(sig.Signature)
+		if err != nil {
+			return err
 		}
-		transmitPayload = append(transmitPayload, "0x"+hex.EncodeToString(signature[32:64])) // r
-		transmitPayload = append(transmitPayload, "0x"+hex.EncodeToString(signature[64:]))   // s
-		transmitPayload = append(transmitPayload, "0x"+hex.EncodeToString(signature[:32]))   // public key
+		signatures = append(signatures, signature)
+	}
+	if err = c.verifySignatures(ctx, reportCtx, report, signatures); err != nil {
+		return err
+	}
+
+	transmitPayload = append(transmitPayload, "0x"+fmt.Sprintf("%x", len(sigs))) // signatures_len
+	for _, sig := range signatures {
+		transmitPayload = append(transmitPayload, sig.R.String(), sig.S.String(), sig.PublicKey.String())
 	}
 
 	// TODO: build felts directly rather than afterwards
@@ -95,13 +146,92 @@ func (c *contractTransmitter) Transmit(
 		return err
 	}
 
-	err = c.txm.Enqueue(ctx, c.accountAddress, c.senderAddress, starknetrpc.FunctionCall{
+	account := c.accounts.pick(c.registeredTransmitters(ctx))
+	c.lggr.Debugw("Transmitting report", "account", account.AccountAddress, "configDigest", reportCtx.ConfigDigest, "epoch", reportCtx.Epoch, "round", reportCtx.Round)
+
+	err = c.txm.Enqueue(ctx, account.AccountAddress, account.PublicKey, starknetrpc.FunctionCall{
 		ContractAddress:    c.contractAddress,
 		EntryPointSelector: starknetutils.GetSelectorFromNameFelt("transmit"),
 		Calldata:           calldata,
 	})
+	if err != nil {
+		return err
+	}
+	promTransmissionsSent.WithLabelValues(c.contractAddress.String(), account.AccountAddress.String()).Inc()
+	return nil
+}
+
+// staleReason returns why the aggregator would reject the report for not having a newer epoch and round than the
+// latest transmission, or an empty string if the report should be transmitted.
+// Both the cached on-chain transmission and the transmissions still pending in the txm are checked.
+func (c *contractTransmitter) staleReason(ctx context.Context, reportCtx types.ReportContext) string {
+	epochAndRound := packEpochAndRound(reportCtx.Epoch, reportCtx.Round)
+
+	configDigest, epoch, round, _, _, err := c.reader.LatestTransmissionDetails(ctx)
+	if err != nil {
+		c.lggr.Debugw("Couldn't fetch latest transmission details, skipping staleness check", "err", err)
+	} else if configDigest == reportCtx.ConfigDigest && packEpochAndRound(epoch, round) >= epochAndRound {
+		return skipReasonTransmitted
+	}
+
+	transmitSelector := starknetutils.GetSelectorFromNameFelt("transmit")
+	for _, call := range c.txm.PendingCalls(c.contractAddress) {
+		if !call.EntryPointSelector.Equal(transmitSelector) {
+			continue
+		}
+		args, err := ParseTransmitCalldata(call.Calldata)
+		if err != nil {
+			c.lggr.Warnw("Couldn't parse pending transmission", "err", err)
+			continue
+		}
+		if args.ConfigDigest == reportCtx.ConfigDigest && packEpochAndRound(args.Epoch, args.Round) >= epochAndRound {
+			return skipReasonPending
+		}
+	}
+	return ""
+}
+
+// registeredTransmitters returns the transmitters of the latest config, or nil if it isn't available
+func (c *contractTransmitter) registeredTransmitters(ctx context.Context) []types.Account {
+	if len(c.accounts.accounts) == 1 {
+		return nil
+	}
+	changedInBlock, _, err := c.configs.LatestConfigDetails(ctx)
+	if err != nil {
+		c.lggr.Warnw("Couldn't fetch latest config, selecting from all transmitter accounts", "err", err)
+		return nil
+	}
+	config, err := c.configs.LatestConfig(ctx, changedInBlock)
+	if err != nil {
+		c.lggr.Warnw("Couldn't fetch latest config, selecting from all transmitter accounts", "err", err)
+		return nil
+	}
+	return config.Transmitters
+}
 
-	return err
+// verifySignatures rejects reports the aggregator would revert on because of their signatures.
+// The check is skipped if the cached config is unavailable or not the one the report was signed for, the contract
+// remains the source of truth in that case.
+func (c *contractTransmitter) verifySignatures(ctx context.Context, reportCtx types.ReportContext, report types.Report, sigs []TransmitSignature) error {
+	changedInBlock, configDigest, err := c.configs.LatestConfigDetails(ctx)
+	if err != nil {
+		c.lggr.Warnw("Couldn't fetch latest config, skipping signature verification", "err", err)
+		return nil
+	}
+	if configDigest != reportCtx.ConfigDigest {
+		c.lggr.Warnw("Report config digest doesn't match the latest config, skipping signature verification", "reportConfigDigest", reportCtx.ConfigDigest, "configDigest", configDigest)
+		return nil
+	}
+	config, err := c.configs.LatestConfig(ctx, changedInBlock)
+	if err != nil {
+		c.lggr.Warnw("Couldn't fetch latest config, skipping signature verification", "err", err)
+		return nil
+	}
+
+	if err = VerifyReportSignatures(config, reportCtx, report, sigs); err != nil {
+		return fmt.Errorf("invalid report signatures: %w", err)
+	}
+	return nil
 }
 
 func (c *contractTransmitter) LatestConfigDigestAndEpoch(
@@ -120,5 +250,5 @@ func (c *contractTransmitter) LatestConfigDigestAndEpoch(
 }
 
 func (c *contractTransmitter) FromAccount(ctx context.Context) (types.Account, error) {
-	return types.Account(c.accountAddress.String()), nil
+	return types.Account(c.accounts.primary().AccountAddress.String()), nil
 }
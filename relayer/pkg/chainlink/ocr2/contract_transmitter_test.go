@@ -0,0 +1,102 @@
+package ocr2
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func TestContractTransmitter_SkipsStaleReports(t *testing.T) {
+	ctx := tests.Context(t)
+	reportCtx, report := testReport(t)
+	signers := newTestSigners(t, 4)
+	config := testConfig(reportCtx, signers)
+	sigs := []types.AttributedOnchainSignature{
+		{Signature: signers[0].sign(t, reportCtx, report), Signer: 0},
+		{Signature: signers[1].sign(t, reportCtx, report), Signer: 1},
+	}
+
+	cache := NewTransmissionsCache(testCacheConfig{}, nil, nil, logger.Test(t))
+	setTransmission := func(digest types.ConfigDigest, epoch uint32, round uint8) {
+		cache.transmissionDetails = TransmissionDetails{Digest: digest, Epoch: epoch, Round: round, LatestAnswer: big.NewInt(0)}
+		cache.tdLastCheckedAt = time.Now()
+	}
+	txm := &fakeTxManager{}
+	transmitter := NewContractTransmitter(cache, staticConfigTracker{config: config}, "0x1234", testAccounts, SelectRoundRobin, nil, txm, logger.Test(t))
+	skipped := func(reason string) float64 {
+		return testutil.ToFloat64(promTransmissionsSkipped.WithLabelValues(transmitter.contractAddress.String(), reason))
+	}
+
+	// epoch 7 round 3 was already accepted
+	setTransmission(reportCtx.ConfigDigest, reportCtx.Epoch, reportCtx.Round)
+	require.NoError(t, transmitter.Transmit(ctx, reportCtx, report, sigs))
+	assert.Empty(t, txm.calls)
+	assert.Equal(t, 1.0, skipped(skipReasonTransmitted))
+
+	// a later round under the previous config doesn't matter
+	setTransmission(types.ConfigDigest{1}, reportCtx.Epoch+1, 0)
+	require.NoError(t, transmitter.Transmit(ctx, reportCtx, report, sigs))
+	require.Len(t, txm.calls, 1)
+
+	setTransmission(reportCtx.ConfigDigest, reportCtx.Epoch, reportCtx.Round-1)
+	require.NoError(t, transmitter.Transmit(ctx, reportCtx, report, sigs))
+	require.Len(t, txm.calls, 2)
+
+	// the same report is still waiting to be confirmed
+	txm.pending = txm.calls
+	require.NoError(t, transmitter.Transmit(ctx, reportCtx, report, sigs))
+	assert.Len(t, txm.calls, 2)
+	assert.Equal(t, 1.0, skipped(skipReasonPending))
+
+	// newer rounds are sent even with older pending transmissions
+	newerCtx := reportCtx
+	newerCtx.Round++
+	newerSigs := []types.AttributedOnchainSignature{
+		{Signature: signers[0].sign(t, newerCtx, report), Signer: 0},
+		{Signature: signers[1].sign(t, newerCtx, report), Signer: 1},
+	}
+	require.NoError(t, transmitter.Transmit(ctx, newerCtx, report, newerSigs))
+	assert.Len(t, txm.calls, 3)
+
+	// the cache being unavailable doesn't block transmissions
+	cache.tdLastCheckedAt = time.Time{}
+	txm.pending = nil
+	require.NoError(t, transmitter.Transmit(ctx, reportCtx, report, sigs))
+	assert.Len(t, txm.calls, 4)
+}
+
+type fakeSequencerStatus struct{ down bool }
+
+func (s *fakeSequencerStatus) SequencerDown() bool { return s.down }
+
+func TestContractTransmitter_PausedWhileSequencerDown(t *testing.T) {
+	ctx := tests.Context(t)
+	reportCtx, report := testReport(t)
+	signers := newTestSigners(t, 4)
+	config := testConfig(reportCtx, signers)
+	sigs := []types.AttributedOnchainSignature{
+		{Signature: signers[0].sign(t, reportCtx, report), Signer: 0},
+		{Signature: signers[1].sign(t, reportCtx, report), Signer: 1},
+	}
+
+	sequencer := &fakeSequencerStatus{down: true}
+	txm := &fakeTxManager{}
+	transmitter := NewContractTransmitter(NewTransmissionsCache(testCacheConfig{}, nil, nil, logger.Test(t)), staticConfigTracker{config: config}, "0x5678", testAccounts, SelectRoundRobin, sequencer, txm, logger.Test(t))
+
+	require.NoError(t, transmitter.Transmit(ctx, reportCtx, report, sigs))
+	assert.Empty(t, txm.calls)
+	assert.Equal(t, 1.0, testutil.ToFloat64(promTransmissionsSkipped.WithLabelValues(transmitter.contractAddress.String(), skipReasonSequencerDown)))
+
+	sequencer.down = false
+	require.NoError(t, transmitter.Transmit(ctx, reportCtx, report, sigs))
+	assert.Len(t, txm.calls, 1)
+}
@@ -145,6 +145,39 @@ func ParseNewTransmissionEvent(event starknetrpc.EmittedEvent) (NewTransmissionE
 	}, nil
 }
 
+// ParseSignedNewTransmissionEvent parses a 'NewTransmission' event of an aggregator storing i128 answers, where the
+// answer and observations are two's complement u128s
+func ParseSignedNewTransmissionEvent(event starknetrpc.EmittedEvent) (NewTransmissionEvent, error) {
+	e, err := ParseNewTransmissionEvent(event)
+	if err != nil {
+		return e, err
+	}
+
+	if e.LatestAnswer, err = medianreport.DecodeI128(e.LatestAnswer); err != nil {
+		return NewTransmissionEvent{}, fmt.Errorf("invalid answer: %w", err)
+	}
+	for i, o := range e.Observations {
+		if e.Observations[i], err = medianreport.DecodeI128(o); err != nil {
+			return NewTransmissionEvent{}, fmt.Errorf("invalid observation %d: %w", i, err)
+		}
+	}
+	return e, nil
+}
+
+// ParseConfigSetPreviousBlock returns the previous_config_block_number key of a 'ConfigSet' event
+func ParseConfigSetPreviousBlock(event starknetrpc.EmittedEvent) (uint64, error) {
+	const constNumOfKeys = 2 + 1 // additional 1 for the automatic event ID key
+	if len(event.Keys) < constNumOfKeys {
+		return 0, errors.New("invalid: event keys")
+	}
+
+	prevBlock := event.Keys[1].BigInt(big.NewInt(0))
+	if !prevBlock.IsUint64() {
+		return 0, fmt.Errorf("previous config block overflows uint64: %s", prevBlock)
+	}
+	return prevBlock.Uint64(), nil
+}
+
 // ParseConfigSetEvent is decoding binary felt data as the libocr ContractConfig type
 func ParseConfigSetEvent(event starknetrpc.EmittedEvent) (types.ContractConfig, error) {
 	eventData := event.Data
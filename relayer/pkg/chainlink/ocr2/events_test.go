@@ -181,3 +181,35 @@ func TestConfigSetEventSelector(t *testing.T) {
 	eventKey.SetBytes(bytes)
 	assert.Equal(t, starknetutils.GetSelectorFromName("ConfigSet").Cmp(eventKey), 0)
 }
+
+func TestSignedNewTransmissionEvent_Parse(t *testing.T) {
+	eventKeys, err := starknetutils.HexArrToFelt(newTransmissionEventKeysRaw)
+	require.NoError(t, err)
+	raw := append([]string{}, newTransmissionEventRaw...)
+	raw[0] = "0xfffffffffffffffffffffffffffffff6" // answer = -10
+	raw[4] = "0xffffffffffffffffffffffffffffffec" // observation = -20
+	eventData, err := starknetutils.HexArrToFelt(raw)
+	require.NoError(t, err)
+
+	event := starknetrpc.EmittedEvent{
+		Event: starknetrpc.Event{
+			Keys: eventKeys,
+			Data: eventData,
+		},
+	}
+	e, err := ParseSignedNewTransmissionEvent(event)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(-10), e.LatestAnswer)
+	require.Equal(t, []*big.Int{big.NewInt(-20), big.NewInt(99), big.NewInt(99), big.NewInt(99)}, e.Observations)
+
+	unsigned, err := ParseNewTransmissionEvent(event)
+	require.NoError(t, err)
+	require.Equal(t, 128, unsigned.LatestAnswer.BitLen())
+
+	raw[0] = "0x100000000000000000000000000000000" // answer overflows u128
+	eventData, err = starknetutils.HexArrToFelt(raw)
+	require.NoError(t, err)
+	event.Data = eventData
+	_, err = ParseSignedNewTransmissionEvent(event)
+	require.ErrorContains(t, err, "invalid answer")
+}
@@ -19,7 +19,11 @@ const (
 // 32 bytes - min
 // 32 bytes - max
 
-type OnchainConfigCodec struct{}
+// OnchainConfigCodec encodes the aggregator's min and max answer.
+// Signed is set for aggregators storing i128 answers, in which case min and max are two's complement u128 felts.
+type OnchainConfigCodec struct {
+	Signed bool
+}
 
 var _ median.OnchainConfigCodec = &OnchainConfigCodec{}
 
@@ -42,7 +46,7 @@ func (codec OnchainConfigCodec) DecodeToFelts(b []byte) ([]*big.Int, error) {
 	return []*big.Int{configVersion, min, max}, nil
 }
 
-// Decode converts the onchainconfig via the outputs of DecodeToFelts into unsigned big.Ints that libocr expects
+// Decode converts the onchainconfig via the outputs of DecodeToFelts into the big.Ints that libocr expects
 func (codec OnchainConfigCodec) Decode(ctx context.Context, b []byte) (median.OnchainConfig, error) {
 	felts, err := codec.DecodeToFelts(b)
 	if err != nil {
@@ -51,6 +55,14 @@ func (codec OnchainConfigCodec) Decode(ctx context.Context, b []byte) (median.On
 
 	min := felts[1]
 	max := felts[2]
+	if codec.Signed {
+		if min, err = DecodeI128(min); err != nil {
+			return median.OnchainConfig{}, fmt.Errorf("invalid OnchainConfig min: %w", err)
+		}
+		if max, err = DecodeI128(max); err != nil {
+			return median.OnchainConfig{}, fmt.Errorf("invalid OnchainConfig max: %w", err)
+		}
+	}
 
 	if !(min.Cmp(max) <= 0) {
 		return median.OnchainConfig{}, fmt.Errorf("OnchainConfig min (%v) should not be greater than max(%v)", min, max)
@@ -60,7 +72,7 @@ func (codec OnchainConfigCodec) Decode(ctx context.Context, b []byte) (median.On
 }
 
 // EncodeFromFelt encodes the config where min & max are big.Int representations of a felt
-// Cairo has no notion of signed values: min and max values will be non-negative
+// Felts are never negative: signed min and max values are passed in their two's complement form
 func (codec OnchainConfigCodec) EncodeFromFelt(version, min, max *big.Int) ([]byte, error) {
 	if version.Uint64() != OnchainConfigVersion {
 		return nil, fmt.Errorf("unexpected version of OnchainConfig, expected %v, got %v", OnchainConfigVersion, version.Int64())
@@ -83,5 +95,15 @@ func (codec OnchainConfigCodec) EncodeFromFelt(version, min, max *big.Int) ([]by
 
 // Encode takes the interface that libocr uses (big.Ints) and serializes it into 3 felts
 func (codec OnchainConfigCodec) Encode(ctx context.Context, c median.OnchainConfig) ([]byte, error) {
-	return codec.EncodeFromFelt(big.NewInt(OnchainConfigVersion), c.Min, c.Max)
+	min, max := c.Min, c.Max
+	if codec.Signed {
+		var err error
+		if min, err = EncodeI128(c.Min); err != nil {
+			return nil, fmt.Errorf("invalid OnchainConfig min: %w", err)
+		}
+		if max, err = EncodeI128(c.Max); err != nil {
+			return nil, fmt.Errorf("invalid OnchainConfig max: %w", err)
+		}
+	}
+	return codec.EncodeFromFelt(big.NewInt(OnchainConfigVersion), min, max)
 }
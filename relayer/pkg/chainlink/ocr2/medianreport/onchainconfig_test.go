@@ -84,3 +84,32 @@ func TestOnchainConfigCodec(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestOnchainConfigCodec_Signed(t *testing.T) {
+	ctx := tests.Context(t)
+	codec := OnchainConfigCodec{Signed: true}
+
+	cfg := median.OnchainConfig{Min: big.NewInt(-1000), Max: big.NewInt(1000)}
+	configBytes, err := codec.Encode(ctx, cfg)
+	require.NoError(t, err)
+
+	// min is stored as a two's complement u128
+	felts, err := codec.DecodeToFelts(configBytes)
+	require.NoError(t, err)
+	expectedMin := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1000))
+	assert.Equal(t, expectedMin, felts[1])
+
+	newCfg, err := codec.Decode(ctx, configBytes)
+	require.NoError(t, err)
+	assert.Equal(t, cfg, newCfg)
+
+	// the same bytes are an inverted range for an unsigned aggregator
+	_, err = OnchainConfigCodec{}.Decode(ctx, configBytes)
+	assert.ErrorContains(t, err, "should not be greater than max")
+
+	_, err = codec.Encode(ctx, median.OnchainConfig{Min: new(big.Int).Lsh(big.NewInt(-1), 128), Max: big.NewInt(0)})
+	assert.ErrorContains(t, err, "overflows i128")
+
+	_, err = OnchainConfigCodec{}.Encode(ctx, cfg)
+	assert.ErrorContains(t, err, "starknet does not support negative values")
+}
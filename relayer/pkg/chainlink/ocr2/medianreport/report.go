@@ -30,7 +30,14 @@ var (
 	observationSizeBytes     = starknet.FeltLength
 )
 
-type ReportCodec struct{}
+// maxObservers is the maximum number of observations a report can hold, matching the aggregator
+const maxObservers = 31
+
+// ReportCodec encodes median reports for the aggregator.
+// Signed is set for aggregators storing i128 answers, in which case observations are encoded as two's complement u128s.
+type ReportCodec struct {
+	Signed bool
+}
 
 func (c ReportCodec) BuildReport(ctx context.Context, oo []median.ParsedAttributedObservation) (types.Report, error) {
 	num := len(oo)
@@ -39,7 +46,7 @@ func (c ReportCodec) BuildReport(ctx context.Context, oo []median.ParsedAttribut
 	}
 
 	for _, o := range oo {
-		if o.Value.Sign() == -1 || o.JuelsPerFeeCoin.Sign() == -1 || o.GasPriceSubunits.Sign() == -1 {
+		if (!c.Signed && o.Value.Sign() == -1) || o.JuelsPerFeeCoin.Sign() == -1 || o.GasPriceSubunits.Sign() == -1 {
 			return nil, fmt.Errorf("starknet does not support negative values: value = (%v), fee = (%v), gas = (%v)", o.Value, o.JuelsPerFeeCoin, o.GasPriceSubunits)
 		}
 	}
@@ -85,7 +92,14 @@ func (c ReportCodec) BuildReport(ctx context.Context, oo []median.ParsedAttribut
 		// where N is the length of the observations array
 		observers[i+1] = byte(o.Observer)
 
-		f := starknetutils.BigIntToFelt(o.Value)
+		value := o.Value
+		if c.Signed {
+			var err error
+			if value, err = EncodeI128(o.Value); err != nil {
+				return nil, fmt.Errorf("invalid observation: %w", err)
+			}
+		}
+		f := starknetutils.BigIntToFelt(value)
 		observations = append(observations, f)
 	}
 
@@ -146,6 +160,12 @@ func (c ReportCodec) MedianFromReport(ctx context.Context, report types.Report)
 		end := start + observationSizeBytes
 		obv := new(felt.Felt).SetBytes(report[start:end])
 		o := obv.BigInt(big.NewInt(0))
+		if c.Signed {
+			var err error
+			if o, err = DecodeI128(o); err != nil {
+				return nil, fmt.Errorf("invalid observation: %w", err)
+			}
+		}
 		oo = append(oo, o)
 	}
 
@@ -161,6 +181,101 @@ func (c ReportCodec) MedianFromReport(ctx context.Context, report types.Report)
 	return oo[n/2], nil
 }
 
+// Report is a decoded median report
+type Report struct {
+	ObservationTimestamp uint64
+	// Observers are the oracle indices, in the same order as the observations
+	Observers       []uint8
+	Observations    []*big.Int
+	JuelsPerFeeCoin *big.Int
+	GasPrice        *big.Int
+}
+
+// Median returns the observation that the aggregator will store as the answer
+func (r Report) Median() (*big.Int, error) {
+	if len(r.Observations) == 0 {
+		return nil, errors.New("report has no observations")
+	}
+	return r.Observations[len(r.Observations)/2], nil
+}
+
+// ParseReport decodes a report produced by BuildReport for an unsigned aggregator
+func ParseReport(report types.Report) (Report, error) {
+	return ReportCodec{}.ParseReport(report)
+}
+
+// ParseReport decodes a report produced by BuildReport. Unlike MedianFromReport the report has to be exactly the
+// expected length and every value has to fit the type used by the aggregator's transmit.
+func (c ReportCodec) ParseReport(report types.Report) (Report, error) {
+	if len(report)%starknet.FeltLength != 0 {
+		return Report{}, fmt.Errorf("invalid report length: %d is not a multiple of %d", len(report), starknet.FeltLength)
+	}
+	if len(report) < prefixSizeBytes+juelsPerFeeCoinSizeBytes+gasPriceSizeBytes {
+		return Report{}, fmt.Errorf("invalid report length: %d is too short", len(report))
+	}
+
+	slices, err := SplitReport(report)
+	if err != nil {
+		return Report{}, err
+	}
+	values := make([]*big.Int, len(slices))
+	for i, s := range slices {
+		values[i] = new(big.Int).SetBytes(s)
+		if values[i].Cmp(curve.Curve.P) != -1 {
+			return Report{}, fmt.Errorf("invalid report: value at offset %d is larger than size of finite field", i*starknet.FeltLength)
+		}
+	}
+
+	// observations_len
+	if !values[2].IsUint64() || values[2].Uint64() == 0 || values[2].Uint64() > maxObservers {
+		return Report{}, fmt.Errorf("invalid number of observations: %v", values[2])
+	}
+	n := int(values[2].Uint64())
+	if expected := 3 + n + 2; len(values) != expected {
+		return Report{}, fmt.Errorf("invalid report length: expected %d felts for %d observations, got %d", expected, n, len(values))
+	}
+
+	// observation_timestamp
+	if !values[0].IsUint64() {
+		return Report{}, fmt.Errorf("invalid observation timestamp: %v", values[0])
+	}
+
+	// observers: [0x01, <1_ID>, <2_ID>, ..., <N_ID>, 0x0, 0x0, ..., 0x0]
+	observers := slices[1]
+	if observers[0] != 1 {
+		return Report{}, fmt.Errorf("invalid observers: expected 0x01 prefix, got 0x%02x", observers[0])
+	}
+	for i := 1 + n; i < len(observers); i++ {
+		if observers[i] != 0 {
+			return Report{}, fmt.Errorf("invalid observers: %d observers set for %d observations", i, n)
+		}
+	}
+
+	r := Report{
+		ObservationTimestamp: values[0].Uint64(),
+		Observers:            append([]uint8{}, observers[1:1+n]...),
+		Observations:         values[3 : 3+n],
+		JuelsPerFeeCoin:      values[3+n],
+		GasPrice:             values[3+n+1],
+	}
+	for i, o := range r.Observations {
+		if o.BitLen() > 128 {
+			return Report{}, fmt.Errorf("invalid observation %d: %v overflows u128", i, o)
+		}
+		if c.Signed {
+			r.Observations[i], _ = DecodeI128(o)
+		}
+	}
+	if r.JuelsPerFeeCoin.BitLen() > 128 {
+		return Report{}, fmt.Errorf("invalid juels per fee coin: %v overflows u128", r.JuelsPerFeeCoin)
+	}
+	if r.GasPrice.BitLen() > 128 {
+		return Report{}, fmt.Errorf("invalid gas price: %v overflows u128", r.GasPrice)
+	}
+
+	return r, nil
+}
+
 func (c ReportCodec) MaxReportLength(ctx context.Context, n int) (int, error) {
 	return prefixSizeBytes + (n * observationSizeBytes) + juelsPerFeeCoinSizeBytes + gasPriceSizeBytes, nil
 }
@@ -238,3 +238,105 @@ func TestMedianFromReport(t *testing.T) {
 		})
 	}
 }
+
+func TestParseReport(t *testing.T) {
+	ctx := tests.Context(t)
+	c := ReportCodec{}
+
+	juels, ok := new(big.Int).SetString("1000000000000000000", 10)
+	require.True(t, ok)
+	oo := []median.ParsedAttributedObservation{
+		{Timestamp: 3, Value: big.NewInt(30), JuelsPerFeeCoin: juels, GasPriceSubunits: big.NewInt(5), Observer: 2},
+		{Timestamp: 1, Value: big.NewInt(10), JuelsPerFeeCoin: juels, GasPriceSubunits: big.NewInt(1), Observer: 0},
+		{Timestamp: 2, Value: big.NewInt(20), JuelsPerFeeCoin: juels, GasPriceSubunits: big.NewInt(3), Observer: 7},
+	}
+	report, err := c.BuildReport(ctx, oo)
+	require.NoError(t, err)
+
+	r, err := ParseReport(report)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), r.ObservationTimestamp)
+	assert.Equal(t, []uint8{0, 7, 2}, r.Observers)
+	assert.Equal(t, []*big.Int{big.NewInt(10), big.NewInt(20), big.NewInt(30)}, r.Observations)
+	assert.Equal(t, juels, r.JuelsPerFeeCoin)
+	assert.Equal(t, big.NewInt(3), r.GasPrice)
+
+	m, err := c.MedianFromReport(ctx, report)
+	require.NoError(t, err)
+	median, err := r.Median()
+	require.NoError(t, err)
+	assert.Equal(t, m, median)
+
+	_, err = Report{}.Median()
+	assert.Error(t, err)
+
+	felt := func(i int) []byte {
+		return report[i*32 : (i+1)*32]
+	}
+	mutate := func(f func(b []byte) []byte) []byte {
+		return f(append([]byte{}, report...))
+	}
+
+	for _, tc := range []struct {
+		name   string
+		report []byte
+		err    string
+	}{
+		{"empty", nil, "too short"},
+		{"not felt aligned", report[:len(report)-1], "not a multiple of 32"},
+		{"trailing felt", append(append([]byte{}, report...), felt(0)...), "expected 8 felts for 3 observations, got 9"},
+		{"missing felt", report[:len(report)-32], "expected 8 felts for 3 observations, got 7"},
+		{"no observations", mutate(func(b []byte) []byte { b[95] = 0; return b }), "invalid number of observations: 0"},
+		{"too many observations", mutate(func(b []byte) []byte { b[95] = 32; return b }), "invalid number of observations: 32"},
+		{"observers prefix", mutate(func(b []byte) []byte { b[32] = 0; return b }), "expected 0x01 prefix"},
+		{"extra observer", mutate(func(b []byte) []byte { b[32+5] = 1; return b }), "observers set for 3 observations"},
+		{"timestamp overflow", mutate(func(b []byte) []byte { b[20] = 1; return b }), "invalid observation timestamp"},
+		{"observation overflow", mutate(func(b []byte) []byte { b[3*32+15] = 1; return b }), "invalid observation 0"},
+		{"gas price overflow", mutate(func(b []byte) []byte { b[7*32+15] = 1; return b }), "invalid gas price"},
+		{"not a felt", mutate(func(b []byte) []byte { b[6*32] = 0xff; return b }), "larger than size of finite field"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseReport(tc.report)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestSignedReport(t *testing.T) {
+	ctx := tests.Context(t)
+	c := ReportCodec{Signed: true}
+
+	oo := []median.ParsedAttributedObservation{
+		{Timestamp: 1, Value: big.NewInt(5), JuelsPerFeeCoin: big.NewInt(1), GasPriceSubunits: big.NewInt(1), Observer: 0},
+		{Timestamp: 1, Value: big.NewInt(-20), JuelsPerFeeCoin: big.NewInt(1), GasPriceSubunits: big.NewInt(1), Observer: 1},
+		{Timestamp: 1, Value: big.NewInt(-3), JuelsPerFeeCoin: big.NewInt(1), GasPriceSubunits: big.NewInt(1), Observer: 2},
+	}
+	report, err := c.BuildReport(ctx, oo)
+	require.NoError(t, err)
+
+	// negative observations are two's complement u128s
+	minus20 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(20))
+	assert.Equal(t, minus20.FillBytes(make([]byte, observationSizeBytes)), []byte(report[prefixSizeBytes:prefixSizeBytes+observationSizeBytes]))
+
+	m, err := c.MedianFromReport(ctx, report)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(-3), m)
+
+	r, err := c.ParseReport(report)
+	require.NoError(t, err)
+	assert.Equal(t, []*big.Int{big.NewInt(-20), big.NewInt(-3), big.NewInt(5)}, r.Observations)
+	assert.Equal(t, []uint8{1, 2, 0}, r.Observers)
+
+	// an unsigned codec sees the negative values as large positive ones, out of order
+	_, err = ReportCodec{}.MedianFromReport(ctx, report)
+	assert.ErrorContains(t, err, "observations not sorted")
+
+	oo[0].Value = new(big.Int).Lsh(big.NewInt(1), 127)
+	_, err = c.BuildReport(ctx, oo)
+	assert.ErrorContains(t, err, "overflows i128")
+
+	oo[0].Value = big.NewInt(1)
+	oo[0].GasPriceSubunits = big.NewInt(-1)
+	_, err = c.BuildReport(ctx, oo)
+	assert.ErrorContains(t, err, "starknet does not support negative values")
+}
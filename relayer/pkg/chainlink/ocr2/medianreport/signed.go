@@ -0,0 +1,37 @@
+package medianreport
+
+import (
+	"fmt"
+	"math/big"
+)
+
+var (
+	// u128Modulus is 2^128, the offset used for two's complement i128 values
+	u128Modulus = new(big.Int).Lsh(big.NewInt(1), 128)
+	// i128Max is 2^127 - 1
+	i128Max = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+	// i128Min is -2^127
+	i128Min = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 127))
+)
+
+// EncodeI128 encodes a signed value as the two's complement u128 felt stored by signed aggregators
+func EncodeI128(v *big.Int) (*big.Int, error) {
+	if v.Cmp(i128Min) < 0 || v.Cmp(i128Max) > 0 {
+		return nil, fmt.Errorf("value %v overflows i128", v)
+	}
+	if v.Sign() >= 0 {
+		return new(big.Int).Set(v), nil
+	}
+	return new(big.Int).Add(v, u128Modulus), nil
+}
+
+// DecodeI128 is the reverse of EncodeI128
+func DecodeI128(v *big.Int) (*big.Int, error) {
+	if v.Sign() < 0 || v.Cmp(u128Modulus) >= 0 {
+		return nil, fmt.Errorf("value %v overflows u128", v)
+	}
+	if v.Cmp(i128Max) <= 0 {
+		return new(big.Int).Set(v), nil
+	}
+	return new(big.Int).Sub(v, u128Modulus), nil
+}
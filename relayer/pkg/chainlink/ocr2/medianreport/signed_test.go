@@ -0,0 +1,41 @@
+package medianreport
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestI128(t *testing.T) {
+	max128 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	for _, tc := range []struct {
+		signed  *big.Int
+		encoded *big.Int
+	}{
+		{big.NewInt(0), big.NewInt(0)},
+		{big.NewInt(1), big.NewInt(1)},
+		{big.NewInt(-1), max128},
+		{i128Max, i128Max},
+		{i128Min, new(big.Int).Lsh(big.NewInt(1), 127)},
+	} {
+		encoded, err := EncodeI128(tc.signed)
+		require.NoError(t, err)
+		assert.Equal(t, tc.encoded, encoded, tc.signed.String())
+
+		decoded, err := DecodeI128(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, tc.signed, decoded, tc.signed.String())
+	}
+
+	_, err := EncodeI128(new(big.Int).Add(i128Max, big.NewInt(1)))
+	assert.ErrorContains(t, err, "overflows i128")
+	_, err = EncodeI128(new(big.Int).Sub(i128Min, big.NewInt(1)))
+	assert.ErrorContains(t, err, "overflows i128")
+
+	_, err = DecodeI128(new(big.Int).Add(max128, big.NewInt(1)))
+	assert.ErrorContains(t, err, "overflows u128")
+	_, err = DecodeI128(big.NewInt(-1))
+	assert.ErrorContains(t, err, "overflows u128")
+}
@@ -0,0 +1,25 @@
+package ocr2
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// reasons for skipping a transmission
+const (
+	skipReasonTransmitted = "already_transmitted"
+	skipReasonPending     = "pending_transmission"
+	// the sequencer uptime feed reports the sequencer as down
+	skipReasonSequencerDown = "sequencer_down"
+)
+
+var promTransmissionsSkipped = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "starknet_ocr2_transmissions_skipped",
+	Help: "Number of reports that were not transmitted because the aggregator would reject them as stale, or the sequencer is down",
+}, []string{"contract", "reason"})
+
+// the aggregator reimburses and pays the account that sent the transmission, which is the one billing is attributed to
+var promTransmissionsSent = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "starknet_ocr2_transmissions_sent",
+	Help: "Number of transmissions enqueued by each transmitter account, the account reimbursed for them by the aggregator",
+}, []string{"contract", "account"})
@@ -40,6 +40,36 @@ func (_m *OCR2Reader) BaseReader() starknet.Reader {
 	return r0
 }
 
+// BillingAccessController provides a mock function with given fields: _a0, _a1
+func (_m *OCR2Reader) BillingAccessController(_a0 context.Context, _a1 *felt.Felt) (*felt.Felt, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BillingAccessController")
+	}
+
+	var r0 *felt.Felt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *felt.Felt) (*felt.Felt, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *felt.Felt) *felt.Felt); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*felt.Felt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *felt.Felt) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BillingDetails provides a mock function with given fields: _a0, _a1
 func (_m *OCR2Reader) BillingDetails(_a0 context.Context, _a1 *felt.Felt) (ocr2.BillingDetails, error) {
 	ret := _m.Called(_a0, _a1)
@@ -96,6 +126,36 @@ func (_m *OCR2Reader) ConfigFromEventAt(_a0 context.Context, _a1 *felt.Felt, _a2
 	return r0, r1
 }
 
+// ConfigHistory provides a mock function with given fields: _a0, _a1, _a2
+func (_m *OCR2Reader) ConfigHistory(_a0 context.Context, _a1 string, _a2 *felt.Felt) ([]ocr2.ConfigHistoryEntry, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfigHistory")
+	}
+
+	var r0 []ocr2.ConfigHistoryEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *felt.Felt) ([]ocr2.ConfigHistoryEntry, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *felt.Felt) []ocr2.ConfigHistoryEntry); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ocr2.ConfigHistoryEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *felt.Felt) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LatestConfigDetails provides a mock function with given fields: _a0, _a1
 func (_m *OCR2Reader) LatestConfigDetails(_a0 context.Context, _a1 *felt.Felt) (ocr2.ContractConfigDetails, error) {
 	ret := _m.Called(_a0, _a1)
@@ -210,28 +270,28 @@ func (_m *OCR2Reader) LinkAvailableForPayment(_a0 context.Context, _a1 *felt.Fel
 	return r0, r1
 }
 
-// NewTransmissionsFromEventsAt provides a mock function with given fields: _a0, _a1, _a2
-func (_m *OCR2Reader) NewTransmissionsFromEventsAt(_a0 context.Context, _a1 *felt.Felt, _a2 uint64) ([]ocr2.NewTransmissionEvent, error) {
+// OwedPayment provides a mock function with given fields: _a0, _a1, _a2
+func (_m *OCR2Reader) OwedPayment(_a0 context.Context, _a1 *felt.Felt, _a2 *felt.Felt) (*big.Int, error) {
 	ret := _m.Called(_a0, _a1, _a2)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NewTransmissionsFromEventsAt")
+		panic("no return value specified for OwedPayment")
 	}
 
-	var r0 []ocr2.NewTransmissionEvent
+	var r0 *big.Int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *felt.Felt, uint64) ([]ocr2.NewTransmissionEvent, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *felt.Felt, *felt.Felt) (*big.Int, error)); ok {
 		return rf(_a0, _a1, _a2)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *felt.Felt, uint64) []ocr2.NewTransmissionEvent); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *felt.Felt, *felt.Felt) *big.Int); ok {
 		r0 = rf(_a0, _a1, _a2)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]ocr2.NewTransmissionEvent)
+			r0 = ret.Get(0).(*big.Int)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *felt.Felt, uint64) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *felt.Felt, *felt.Felt) error); ok {
 		r1 = rf(_a0, _a1, _a2)
 	} else {
 		r1 = ret.Error(1)
@@ -240,6 +300,36 @@ func (_m *OCR2Reader) NewTransmissionsFromEventsAt(_a0 context.Context, _a1 *fel
 	return r0, r1
 }
 
+// NewTransmissionsFromEventsAt provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *OCR2Reader) NewTransmissionsFromEventsAt(_a0 context.Context, _a1 *felt.Felt, _a2 uint64, _a3 bool) ([]ocr2.NewTransmissionEvent, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewTransmissionsFromEventsAt")
+	}
+
+	var r0 []ocr2.NewTransmissionEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *felt.Felt, uint64, bool) ([]ocr2.NewTransmissionEvent, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *felt.Felt, uint64, bool) []ocr2.NewTransmissionEvent); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ocr2.NewTransmissionEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *felt.Felt, uint64, bool) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewOCR2Reader creates a new instance of OCR2Reader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewOCR2Reader(t interface {
@@ -0,0 +1,54 @@
+package ocr2
+
+import (
+	"github.com/NethermindEth/juno/core/felt"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+)
+
+// PayeeConfig assigns the address a transmitter's payments are withdrawn to
+type PayeeConfig struct {
+	Transmitter *felt.Felt
+	Payee       *felt.Felt
+}
+
+// WithdrawPaymentCall builds a call paying out the LINK owed to transmitter, it has to be sent by its payee
+func WithdrawPaymentCall(aggregator, transmitter *felt.Felt) starknetrpc.FunctionCall {
+	return starknetrpc.FunctionCall{
+		ContractAddress:    aggregator,
+		EntryPointSelector: starknetutils.GetSelectorFromNameFelt("withdraw_payment"),
+		Calldata:           []*felt.Felt{transmitter},
+	}
+}
+
+// SetPayeesCall builds a call setting the initial payees, it has to be sent by the aggregator owner.
+// The aggregator only accepts payees that are unset or unchanged, use TransferPayeeshipCall to change them.
+func SetPayeesCall(aggregator *felt.Felt, payees []PayeeConfig) starknetrpc.FunctionCall {
+	calldata := []*felt.Felt{new(felt.Felt).SetUint64(uint64(len(payees)))}
+	for _, p := range payees {
+		calldata = append(calldata, p.Transmitter, p.Payee)
+	}
+	return starknetrpc.FunctionCall{
+		ContractAddress:    aggregator,
+		EntryPointSelector: starknetutils.GetSelectorFromNameFelt("set_payees"),
+		Calldata:           calldata,
+	}
+}
+
+// TransferPayeeshipCall builds a call proposing a new payee for transmitter, it has to be sent by the current payee
+func TransferPayeeshipCall(aggregator, transmitter, proposed *felt.Felt) starknetrpc.FunctionCall {
+	return starknetrpc.FunctionCall{
+		ContractAddress:    aggregator,
+		EntryPointSelector: starknetutils.GetSelectorFromNameFelt("transfer_payeeship"),
+		Calldata:           []*felt.Felt{transmitter, proposed},
+	}
+}
+
+// AcceptPayeeshipCall builds a call accepting the payeeship of transmitter, it has to be sent by the proposed payee
+func AcceptPayeeshipCall(aggregator, transmitter *felt.Felt) starknetrpc.FunctionCall {
+	return starknetrpc.FunctionCall{
+		ContractAddress:    aggregator,
+		EntryPointSelector: starknetutils.GetSelectorFromNameFelt("accept_payeeship"),
+		Calldata:           []*felt.Felt{transmitter},
+	}
+}
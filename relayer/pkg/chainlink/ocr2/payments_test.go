@@ -0,0 +1,39 @@
+package ocr2
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentCalls(t *testing.T) {
+	aggregator := new(felt.Felt).SetUint64(0x1234)
+	felts := func(values ...uint64) (out []*felt.Felt) {
+		for _, v := range values {
+			out = append(out, new(felt.Felt).SetUint64(v))
+		}
+		return out
+	}
+
+	call := WithdrawPaymentCall(aggregator, felts(1)[0])
+	assert.Equal(t, aggregator, call.ContractAddress)
+	assert.Equal(t, starknetutils.GetSelectorFromNameFelt("withdraw_payment"), call.EntryPointSelector)
+	assert.Equal(t, felts(1), call.Calldata)
+
+	call = SetPayeesCall(aggregator, []PayeeConfig{
+		{Transmitter: felts(1)[0], Payee: felts(11)[0]},
+		{Transmitter: felts(2)[0], Payee: felts(12)[0]},
+	})
+	assert.Equal(t, starknetutils.GetSelectorFromNameFelt("set_payees"), call.EntryPointSelector)
+	assert.Equal(t, felts(2, 1, 11, 2, 12), call.Calldata)
+
+	call = TransferPayeeshipCall(aggregator, felts(1)[0], felts(13)[0])
+	assert.Equal(t, starknetutils.GetSelectorFromNameFelt("transfer_payeeship"), call.EntryPointSelector)
+	assert.Equal(t, felts(1, 13), call.Calldata)
+
+	call = AcceptPayeeshipCall(aggregator, felts(1)[0])
+	assert.Equal(t, starknetutils.GetSelectorFromNameFelt("accept_payeeship"), call.EntryPointSelector)
+	assert.Equal(t, felts(1), call.Calldata)
+}
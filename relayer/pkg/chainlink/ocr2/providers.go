@@ -2,8 +2,11 @@ package ocr2
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+
 	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/median"
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 
@@ -24,25 +27,40 @@ type configProvider struct {
 	reader        Reader
 	contractCache *contractCache
 	digester      types.OffchainConfigDigester
+	refreshed     func() <-chan struct{}
+	unregister    func()
 
 	lggr logger.Logger
 }
 
-func NewConfigProvider(chainID string, contractAddress string, basereader starknet.Reader, cfg Config, lggr logger.Logger) (*configProvider, error) {
+// NewConfigProvider creates the config provider for an aggregator. The contract is registered with the chain's
+// stateCache, its config details are read from the shared cache and refreshed with it instead of being polled.
+func NewConfigProvider(chainID string, contractAddress string, signedAnswers bool, basereader starknet.Reader, stateCache *StateCache, cfg Config, lggr logger.Logger) (*configProvider, error) {
 	lggr = logger.Named(lggr, "ConfigProvider")
 	chainReader, err := NewClient(basereader, lggr)
 	if err != nil {
 		return nil, fmt.Errorf("err in NewConfigProvider.NewClient: %w", err)
 	}
 
-	reader := NewContractReader(contractAddress, chainReader, lggr)
-	cache := NewContractCache(cfg, reader, lggr)
+	if stateCache == nil {
+		return nil, errors.New("state cache is required")
+	}
+	address, err := starknetutils.HexToFelt(contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid contract address %s: %w", contractAddress, err)
+	}
+	reader := stateCache.NewReader(address, NewContractReader(contractAddress, chainReader, signedAnswers, lggr), signedAnswers)
+	unregister := stateCache.Register(address)
+	refreshed := func() <-chan struct{} { return stateCache.Refreshed(address) }
+	cache := NewContractCache(cfg, reader, refreshed, lggr)
 	digester := NewOffchainConfigDigester(chainID, contractAddress)
 
 	return &configProvider{
 		reader:        reader,
 		contractCache: cache,
 		digester:      digester,
+		refreshed:     refreshed,
+		unregister:    unregister,
 		lggr:          lggr,
 	}, nil
 }
@@ -61,10 +79,15 @@ func (p *configProvider) Start(context.Context) error {
 func (p *configProvider) Close() error {
 	return p.StopOnce("ConfigProvider", func() error {
 		p.lggr.Debugf("Config provider stopping")
+		p.unregisterState()
 		return p.contractCache.Close()
 	})
 }
 
+func (p *configProvider) unregisterState() {
+	p.unregister()
+}
+
 func (p *configProvider) HealthReport() map[string]error {
 	return map[string]error{p.Name(): p.Healthy()}
 }
@@ -84,23 +107,30 @@ type medianProvider struct {
 	transmitter        types.ContractTransmitter
 	transmissionsCache *transmissionsCache
 	reportCodec        median.ReportCodec
+	onchainConfigCodec median.OnchainConfigCodec
 }
 
-func NewMedianProvider(chainID string, contractAddress string, senderAddress string, accountAddress string, basereader starknet.Reader, cfg Config, txm txm.TxManager, lggr logger.Logger) (*medianProvider, error) {
+// NewMedianProvider creates the provider for an aggregator. signedAnswers is set for aggregators storing i128 answers.
+// Transmissions are sent from accounts, see NewContractTransmitter.
+func NewMedianProvider(chainID string, contractAddress string, accounts []TransmitterAccount, selection TransmitterSelection, signedAnswers bool, basereader starknet.Reader, stateCache *StateCache, sequencer SequencerStatus, cfg Config, txm txm.TxManager, lggr logger.Logger) (*medianProvider, error) {
 	lggr = logger.Named(lggr, "MedianProvider")
-	configProvider, err := NewConfigProvider(chainID, contractAddress, basereader, cfg, lggr)
+	if len(accounts) == 0 {
+		return nil, errors.New("no transmitter accounts")
+	}
+	configProvider, err := NewConfigProvider(chainID, contractAddress, signedAnswers, basereader, stateCache, cfg, lggr)
 	if err != nil {
 		return nil, fmt.Errorf("error in NewMedianProvider.NewConfigProvider: %w", err)
 	}
 
-	cache := NewTransmissionsCache(cfg, configProvider.reader, lggr)
-	transmitter := NewContractTransmitter(cache, contractAddress, senderAddress, accountAddress, txm)
+	cache := NewTransmissionsCache(cfg, configProvider.reader, configProvider.refreshed, lggr)
+	transmitter := NewContractTransmitter(cache, configProvider.contractCache, contractAddress, accounts, selection, sequencer, txm, lggr)
 
 	return &medianProvider{
 		configProvider:     configProvider,
 		transmitter:        transmitter,
 		transmissionsCache: cache,
-		reportCodec:        medianreport.ReportCodec{},
+		reportCodec:        medianreport.ReportCodec{Signed: signedAnswers},
+		onchainConfigCodec: medianreport.OnchainConfigCodec{Signed: signedAnswers},
 	}, nil
 }
 
@@ -123,6 +153,7 @@ func (p *medianProvider) Start(context.Context) error {
 func (p *medianProvider) Close() error {
 	return p.StopOnce("MedianProvider", func() error {
 		p.lggr.Debugf("Median provider stopping")
+		p.configProvider.unregisterState()
 		// stopping both cache services here
 		// todo: find a better way
 		if err := p.configProvider.contractCache.Close(); err != nil {
@@ -149,7 +180,7 @@ func (p *medianProvider) MedianContract() median.MedianContract {
 }
 
 func (p *medianProvider) OnchainConfigCodec() median.OnchainConfigCodec {
-	return medianreport.OnchainConfigCodec{}
+	return p.onchainConfigCodec
 }
 
 func (p *medianProvider) ContractReader() relaytypes.ContractReader {
@@ -0,0 +1,95 @@
+package ocr2
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/starknet.go/curve"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2/medianreport"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+)
+
+// HashReport computes the message signed by the oracles, matching the aggregator's hash_report:
+// a pedersen hash chain over the report context and the report felts, followed by their count
+func HashReport(reportCtx types.ReportContext, report types.Report) (*felt.Felt, error) {
+	slices, err := medianreport.SplitReport(report)
+	if err != nil {
+		return nil, err
+	}
+
+	var msg []*big.Int
+	for _, r := range medianreport.RawReportContext(reportCtx) {
+		msg = append(msg, new(big.Int).SetBytes(r[:]))
+	}
+	for _, s := range slices {
+		msg = append(msg, new(big.Int).SetBytes(s))
+	}
+
+	hash, err := curve.Curve.ComputeHashOnElements(msg)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't hash report: %w", err)
+	}
+	return starknetutils.BigIntToFelt(hash), nil
+}
+
+// ParseOnchainSignature splits a libocr onchain signature: 32 byte public key + 32 byte R + 32 byte S
+func ParseOnchainSignature(signature []byte) (TransmitSignature, error) {
+	if len(signature) != 32+32+32 {
+		return TransmitSignature{}, errors.New("invalid length of the signature")
+	}
+	return TransmitSignature{
+		R:         new(felt.Felt).SetBytes(signature[32:64]),
+		S:         new(felt.Felt).SetBytes(signature[64:]),
+		PublicKey: new(felt.Felt).SetBytes(signature[:32]),
+	}, nil
+}
+
+// VerifyReportSignatures performs the signature checks of the aggregator's transmit against a config:
+// exactly f+1 signatures from distinct signers of the config, each valid for the report hash.
+func VerifyReportSignatures(config types.ContractConfig, reportCtx types.ReportContext, report types.Report, sigs []TransmitSignature) error {
+	if reportCtx.ConfigDigest != config.ConfigDigest {
+		return fmt.Errorf("config digest mismatch: report %s, config %s", reportCtx.ConfigDigest, config.ConfigDigest)
+	}
+	if len(sigs) != int(config.F)+1 {
+		return fmt.Errorf("wrong number of signatures: expected %d, got %d", int(config.F)+1, len(sigs))
+	}
+
+	hash, err := HashReport(reportCtx, report)
+	if err != nil {
+		return err
+	}
+	msg := hash.BigInt(big.NewInt(0))
+
+	seen := map[int]bool{}
+	for i, sig := range sigs {
+		publicKey := sig.PublicKey.Bytes()
+		index := -1
+		for j, signer := range config.Signers {
+			if bytes.Equal(starknet.PadBytes(signer, starknet.FeltLength), publicKey[:]) {
+				index = j
+				break
+			}
+		}
+		if index == -1 {
+			return fmt.Errorf("signature %d: invalid signer %s", i, sig.PublicKey)
+		}
+		if seen[index] {
+			return fmt.Errorf("signature %d: duplicate signer %s", i, sig.PublicKey)
+		}
+		seen[index] = true
+
+		x := sig.PublicKey.BigInt(big.NewInt(0))
+		y := curve.Curve.GetYCoordinate(x)
+		if y == nil || !curve.Curve.Verify(msg, sig.R.BigInt(big.NewInt(0)), sig.S.BigInt(big.NewInt(0)), x, y) {
+			return fmt.Errorf("signature %d: invalid signature by %s", i, sig.PublicKey)
+		}
+	}
+	return nil
+}
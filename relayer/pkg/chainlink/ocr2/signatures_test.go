@@ -0,0 +1,188 @@
+package ocr2
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/starknet.go/curve"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/median"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2/medianreport"
+)
+
+type testSigner struct {
+	privateKey *big.Int
+	publicKey  *big.Int
+}
+
+func newTestSigners(t *testing.T, n int) (signers []testSigner) {
+	for i := 0; i < n; i++ {
+		privateKey, err := curve.Curve.GetRandomPrivateKey()
+		require.NoError(t, err)
+		publicKey, _, err := curve.Curve.PrivateToPoint(privateKey)
+		require.NoError(t, err)
+		signers = append(signers, testSigner{privateKey: privateKey, publicKey: publicKey})
+	}
+	return signers
+}
+
+// sign produces a libocr onchain signature: 32 byte public key + 32 byte R + 32 byte S
+func (s testSigner) sign(t *testing.T, reportCtx types.ReportContext, report types.Report) []byte {
+	hash, err := HashReport(reportCtx, report)
+	require.NoError(t, err)
+	r, sig, err := curve.Curve.Sign(hash.BigInt(big.NewInt(0)), s.privateKey)
+	require.NoError(t, err)
+
+	var out []byte
+	out = append(out, s.publicKey.FillBytes(make([]byte, 32))...)
+	out = append(out, r.FillBytes(make([]byte, 32))...)
+	out = append(out, sig.FillBytes(make([]byte, 32))...)
+	return out
+}
+
+func testReport(t *testing.T) (types.ReportContext, types.Report) {
+	report, err := medianreport.ReportCodec{}.BuildReport(tests.Context(t), []median.ParsedAttributedObservation{
+		{Timestamp: 1, Value: big.NewInt(10), JuelsPerFeeCoin: big.NewInt(2), GasPriceSubunits: big.NewInt(3), Observer: commontypes.OracleID(1)},
+		{Timestamp: 2, Value: big.NewInt(20), JuelsPerFeeCoin: big.NewInt(2), GasPriceSubunits: big.NewInt(3), Observer: commontypes.OracleID(0)},
+	})
+	require.NoError(t, err)
+
+	reportCtx := types.ReportContext{ReportTimestamp: types.ReportTimestamp{Epoch: 7, Round: 3}}
+	reportCtx.ConfigDigest[1], reportCtx.ConfigDigest[31] = 0x04, 0xff
+	reportCtx.ExtraHash[0], reportCtx.ExtraHash[31] = 0xff, 0x1 // first byte is dropped to fit a felt
+	return reportCtx, report
+}
+
+func testConfig(reportCtx types.ReportContext, signers []testSigner) types.ContractConfig {
+	config := types.ContractConfig{ConfigDigest: reportCtx.ConfigDigest, F: 1}
+	for _, s := range signers {
+		config.Signers = append(config.Signers, s.publicKey.FillBytes(make([]byte, 32)))
+		config.Transmitters = append(config.Transmitters, "0x1")
+	}
+	return config
+}
+
+func TestHashReport(t *testing.T) {
+	reportCtx, report := testReport(t)
+	hash, err := HashReport(reportCtx, report)
+	require.NoError(t, err)
+
+	// mirror the aggregator's hash_report, one LegacyHash::hash per field
+	felts := []*big.Int{
+		new(big.Int).SetBytes(reportCtx.ConfigDigest[:]),
+		big.NewInt(7<<8 | 3), // epoch_and_round
+		new(big.Int).SetBytes(append([]byte{0}, reportCtx.ExtraHash[1:]...)),
+	}
+	slices, err := medianreport.SplitReport(report)
+	require.NoError(t, err)
+	for _, s := range slices {
+		felts = append(felts, new(big.Int).SetBytes(s))
+	}
+	// len = 5 + 1 + observations.len() + 2
+	felts = append(felts, big.NewInt(5+1+2+2))
+	state := big.NewInt(0)
+	for _, f := range felts {
+		state, err = curve.Curve.PedersenHash([]*big.Int{state, f})
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, starknetutils.BigIntToFelt(state), hash)
+}
+
+func TestVerifyReportSignatures(t *testing.T) {
+	reportCtx, report := testReport(t)
+	signers := newTestSigners(t, 4)
+	config := testConfig(reportCtx, signers)
+
+	sig := func(s testSigner) TransmitSignature {
+		parsed, err := ParseOnchainSignature(s.sign(t, reportCtx, report))
+		require.NoError(t, err)
+		return parsed
+	}
+	valid := []TransmitSignature{sig(signers[0]), sig(signers[2])}
+	require.NoError(t, VerifyReportSignatures(config, reportCtx, report, valid))
+
+	outsider := newTestSigners(t, 1)[0]
+	tampered := sig(signers[2])
+	tampered.S = new(felt.Felt).Add(tampered.S, new(felt.Felt).SetUint64(1))
+
+	for _, tc := range []struct {
+		name string
+		sigs []TransmitSignature
+		err  string
+	}{
+		{"too few", valid[:1], "wrong number of signatures: expected 2, got 1"},
+		{"unknown signer", []TransmitSignature{valid[0], sig(outsider)}, "signature 1: invalid signer"},
+		{"duplicate signer", []TransmitSignature{valid[0], valid[0]}, "signature 1: duplicate signer"},
+		{"invalid signature", []TransmitSignature{valid[0], tampered}, "signature 1: invalid signature"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ErrorContains(t, VerifyReportSignatures(config, reportCtx, report, tc.sigs), tc.err)
+		})
+	}
+
+	t.Run("different report", func(t *testing.T) {
+		other := append(types.Report{}, report...)
+		other[len(other)-1]++
+		assert.ErrorContains(t, VerifyReportSignatures(config, reportCtx, other, valid), "invalid signature")
+	})
+
+	t.Run("config digest mismatch", func(t *testing.T) {
+		other := config
+		other.ConfigDigest[31] = 0
+		assert.ErrorContains(t, VerifyReportSignatures(other, reportCtx, report, valid), "config digest mismatch")
+	})
+}
+
+// staticConfigTracker serves a fixed config
+type staticConfigTracker struct {
+	types.ContractConfigTracker
+	config types.ContractConfig
+}
+
+func (s staticConfigTracker) LatestConfigDetails(context.Context) (uint64, types.ConfigDigest, error) {
+	return 1, s.config.ConfigDigest, nil
+}
+
+func (s staticConfigTracker) LatestConfig(context.Context, uint64) (types.ContractConfig, error) {
+	return s.config, nil
+}
+
+func TestContractTransmitter_VerifiesSignatures(t *testing.T) {
+	ctx := tests.Context(t)
+	reportCtx, report := testReport(t)
+	signers := newTestSigners(t, 4)
+	config := testConfig(reportCtx, signers)
+
+	txm := &fakeTxManager{}
+	transmitter := NewContractTransmitter(NewTransmissionsCache(testCacheConfig{}, nil, nil, logger.Test(t)), staticConfigTracker{config: config}, "0x1234", testAccounts, SelectRoundRobin, nil, txm, logger.Test(t))
+
+	sigs := []types.AttributedOnchainSignature{
+		{Signature: signers[0].sign(t, reportCtx, report), Signer: 0},
+		{Signature: signers[1].sign(t, reportCtx, report), Signer: 1},
+	}
+	require.NoError(t, transmitter.Transmit(ctx, reportCtx, report, sigs))
+	require.Len(t, txm.calls, 1)
+
+	// signed by a key that isn't part of the config
+	sigs[1].Signature = newTestSigners(t, 1)[0].sign(t, reportCtx, report)
+	err := transmitter.Transmit(ctx, reportCtx, report, sigs)
+	assert.ErrorContains(t, err, "invalid report signatures: signature 1: invalid signer")
+	assert.Len(t, txm.calls, 1)
+
+	// reports for a different config can't be checked, the contract has the final say
+	transmitter.configs = staticConfigTracker{config: types.ContractConfig{}}
+	require.NoError(t, transmitter.Transmit(ctx, reportCtx, report, sigs))
+	assert.Len(t, txm.calls, 2)
+}
@@ -0,0 +1,336 @@
+package ocr2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+)
+
+// maxBatchCalls limits the number of calls sent in a single batch request
+const maxBatchCalls = 100
+
+var _ services.Service = (*StateCache)(nil)
+
+// contractState is the latest on-chain state of an aggregator, as seen by the StateCache
+type contractState struct {
+	refs int
+	// refreshed is closed and replaced on every refresh of the contract
+	refreshed chan struct{}
+
+	refreshedAt uint64 // 0 until the first successful refresh
+	// checkedAt is the time of the last successful refresh, contracts whose calls keep failing expire on their own
+	checkedAt    time.Time
+	config       ContractConfigDetails
+	transmission TransmissionDetails
+}
+
+// StateCache is a chain level cache of the config and transmission details of every registered aggregator.
+// All contracts are refreshed together, in batched calls pinned to the same block, and served to the providers
+// through the Reader returned by NewReader. The caches of the providers update on Refreshed, so the chain is polled
+// once for all contracts.
+type StateCache struct {
+	utils.StartStopOnce
+
+	// the client is reused across polls, a failed poll picks a node again
+	client *utils.LazyLoad[starknet.ChainClient]
+	cfg    Config
+	lggr   logger.Logger
+
+	lock        sync.RWMutex
+	contracts   map[string]*contractState
+	blockHeight uint64
+
+	trigger    chan struct{}
+	stop, done chan struct{}
+}
+
+func NewStateCache(cfg Config, getClient func() (starknet.ChainClient, error), lggr logger.Logger) *StateCache {
+	return &StateCache{
+		client:    utils.NewLazyLoad(getClient),
+		cfg:       cfg,
+		lggr:      logger.Named(lggr, "OCR2StateCache"),
+		contracts: map[string]*contractState{},
+		trigger:   make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+func (c *StateCache) Name() string {
+	return c.lggr.Name()
+}
+
+func (c *StateCache) Start(context.Context) error {
+	return c.StartOnce("OCR2StateCache", func() error {
+		go c.poll()
+		return nil
+	})
+}
+
+func (c *StateCache) Close() error {
+	return c.StopOnce("OCR2StateCache", func() error {
+		close(c.stop)
+		<-c.done
+		return nil
+	})
+}
+
+func (c *StateCache) HealthReport() map[string]error {
+	return map[string]error{c.Name(): c.Healthy()}
+}
+
+// Register adds a contract to the cache, the returned function removes it once all its users are done.
+// A refresh is triggered right away so new contracts don't wait for the next poll.
+func (c *StateCache) Register(address *felt.Felt) (unregister func()) {
+	key := address.String()
+
+	c.lock.Lock()
+	state, ok := c.contracts[key]
+	if !ok {
+		state = &contractState{refreshed: make(chan struct{})}
+		c.contracts[key] = state
+	}
+	state.refs++
+	c.lock.Unlock()
+
+	if !ok {
+		select {
+		case c.trigger <- struct{}{}:
+		default:
+		}
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.lock.Lock()
+			defer c.lock.Unlock()
+			if state.refs--; state.refs == 0 {
+				delete(c.contracts, key)
+			}
+		})
+	}
+}
+
+func (c *StateCache) poll() {
+	defer close(c.done)
+	tick := time.After(0)
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-tick:
+		case <-c.trigger:
+		}
+
+		ctx, cancel := utils.ContextFromChan(c.stop)
+		if err := c.refresh(ctx); err != nil {
+			c.lggr.Errorf("Failed to refresh contract states: %v", err)
+		}
+		cancel()
+
+		tick = time.After(utils.WithJitter(c.cfg.OCR2CachePollPeriod()))
+	}
+}
+
+// refresh reads latest_config_details and latest_transmission_details of every registered contract at the latest block
+func (c *StateCache) refresh(ctx context.Context) error {
+	c.lock.RLock()
+	addresses := make([]*felt.Felt, 0, len(c.contracts))
+	for key := range c.contracts {
+		address, err := starknetutils.HexToFelt(key)
+		if err != nil {
+			c.lock.RUnlock()
+			return fmt.Errorf("invalid contract address %s: %w", key, err)
+		}
+		addresses = append(addresses, address)
+	}
+	c.lock.RUnlock()
+
+	client, err := c.client.Get()
+	if err != nil {
+		return fmt.Errorf("couldn't get client: %w", err)
+	}
+	head, err := client.LatestBlockHashAndNumber(ctx)
+	if err != nil {
+		c.client.Reset()
+		return fmt.Errorf("couldn't fetch latest block: %w", err)
+	}
+	block := starknetrpc.WithBlockNumber(head.BlockNumber)
+
+	configSelector := starknetutils.GetSelectorFromNameFelt("latest_config_details")
+	transmissionSelector := starknetutils.GetSelectorFromNameFelt("latest_transmission_details")
+
+	type result struct {
+		config       ContractConfigDetails
+		transmission TransmissionDetails
+	}
+	results := map[string]result{}
+	// every contract takes two calls
+	for start := 0; start < len(addresses); start += maxBatchCalls / 2 {
+		chunk := addresses[start:min(start+maxBatchCalls/2, len(addresses))]
+
+		builder := starknet.NewBatchBuilder()
+		for _, address := range chunk {
+			builder.RequestCall(starknetrpc.FunctionCall{ContractAddress: address, EntryPointSelector: configSelector}, block)
+			builder.RequestCall(starknetrpc.FunctionCall{ContractAddress: address, EntryPointSelector: transmissionSelector}, block)
+		}
+		elems, err := client.Batch(ctx, builder)
+		if err != nil {
+			c.client.Reset()
+			return fmt.Errorf("couldn't batch contract calls: %w", err)
+		}
+
+		for i, address := range chunk {
+			config, err := batchResult(elems[2*i], parseConfigDetails)
+			if err != nil {
+				c.lggr.Errorw("Failed to fetch latest config details", "contract", address, "err", err)
+				continue
+			}
+			transmission, err := batchResult(elems[2*i+1], parseTransmissionDetails)
+			if err != nil {
+				c.lggr.Errorw("Failed to fetch latest transmission details", "contract", address, "err", err)
+				continue
+			}
+			results[address.String()] = result{config: config, transmission: transmission}
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if head.BlockNumber < c.blockHeight {
+		// the node is behind the one used for the previous refresh, keep the newer state
+		return nil
+	}
+	c.blockHeight = head.BlockNumber
+	now := time.Now()
+	for key, r := range results {
+		state, ok := c.contracts[key]
+		if !ok {
+			continue // unregistered while refreshing
+		}
+		state.refreshedAt = head.BlockNumber
+		state.checkedAt = now
+		state.config = r.config
+		state.transmission = r.transmission
+		close(state.refreshed)
+		state.refreshed = make(chan struct{})
+	}
+	c.lggr.Debugw("contract states refreshed", "blockHeight", head.BlockNumber, "contracts", len(addresses), "refreshed", len(results))
+
+	return nil
+}
+
+func batchResult[T any](elem gethrpc.BatchElem, parse func([]*felt.Felt) (T, error)) (out T, err error) {
+	if elem.Error != nil {
+		return out, elem.Error
+	}
+	res, ok := elem.Result.(*[]*felt.Felt)
+	if !ok || res == nil {
+		return out, fmt.Errorf("unexpected result type %T", elem.Result)
+	}
+	return parse(*res)
+}
+
+// Refreshed returns a channel that is closed on the next successful refresh of a registered contract. The channel of
+// a contract that isn't registered is never closed.
+func (c *StateCache) Refreshed(address *felt.Felt) <-chan struct{} {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if state, ok := c.contracts[address.String()]; ok {
+		return state.refreshed
+	}
+	return nil
+}
+
+// state returns a copy of the state of a registered contract
+func (c *StateCache) state(address *felt.Felt) (contractState, uint64, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	state, ok := c.contracts[address.String()]
+	if !ok {
+		return contractState{}, 0, fmt.Errorf("contract %s is not registered", address)
+	}
+	if state.refreshedAt == 0 {
+		return contractState{}, 0, errors.New("contract state not yet initialized")
+	}
+	if since := time.Since(state.checkedAt); since > c.cfg.OCR2CacheTTL() {
+		return contractState{}, 0, fmt.Errorf("contract state expired: checked last %s ago", since)
+	}
+	return *state, c.blockHeight, nil
+}
+
+// NewReader wraps a contract reader so the config and transmission details are served from the cache.
+// The config itself is still read from the ConfigSet event, which only happens when the digest changes.
+func (c *StateCache) NewReader(address *felt.Felt, reader Reader, signedAnswers bool) Reader {
+	return &cachedContractReader{
+		Reader:        reader,
+		address:       address,
+		cache:         c,
+		signedAnswers: signedAnswers,
+	}
+}
+
+var _ Reader = (*cachedContractReader)(nil)
+
+type cachedContractReader struct {
+	Reader
+
+	address       *felt.Felt
+	cache         *StateCache
+	signedAnswers bool
+}
+
+func (c *cachedContractReader) LatestConfigDetails(ctx context.Context) (changedInBlock uint64, configDigest types.ConfigDigest, err error) {
+	state, _, err := c.cache.state(c.address)
+	if err != nil {
+		return 0, configDigest, fmt.Errorf("couldn't get latest config details: %w", err)
+	}
+	return state.config.Block, state.config.Digest, nil
+}
+
+func (c *cachedContractReader) LatestBlockHeight(ctx context.Context) (uint64, error) {
+	_, blockHeight, err := c.cache.state(c.address)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get latest block height: %w", err)
+	}
+	return blockHeight, nil
+}
+
+func (c *cachedContractReader) LatestTransmissionDetails(
+	ctx context.Context,
+) (
+	configDigest types.ConfigDigest,
+	epoch uint32,
+	round uint8,
+	latestAnswer *big.Int,
+	latestTimestamp time.Time,
+	err error,
+) {
+	state, _, err := c.cache.state(c.address)
+	if err != nil {
+		err = fmt.Errorf("couldn't get transmission details: %w", err)
+		return
+	}
+
+	td := state.transmission
+	latestAnswer, err = decodeAnswer(td.LatestAnswer, c.signedAnswers)
+	return td.Digest, td.Epoch, td.Round, latestAnswer, td.LatestTimestamp, err
+}
@@ -0,0 +1,206 @@
+package ocr2
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+)
+
+// fakeChainClient answers latest_config_details and latest_transmission_details from in-memory results
+type fakeChainClient struct {
+	starknet.ChainClient
+
+	lock        sync.Mutex
+	blockNumber uint64
+	headErr     error
+	results     map[string][]*felt.Felt // keyed by address and selector
+	batches     [][]gethrpc.BatchElem
+}
+
+func (f *fakeChainClient) set(address *felt.Felt, method string, res ...uint64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	key := address.String() + method
+	if res == nil {
+		delete(f.results, key)
+		return
+	}
+	f.results[key] = starknetutils.Map(res, func(v uint64) *felt.Felt { return new(felt.Felt).SetUint64(v) })
+}
+
+func (f *fakeChainClient) LatestBlockHashAndNumber(ctx context.Context) (starknetrpc.BlockHashAndNumberOutput, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return starknetrpc.BlockHashAndNumberOutput{BlockNumber: f.blockNumber}, f.headErr
+}
+
+func (f *fakeChainClient) Batch(ctx context.Context, builder starknet.BatchBuilder) ([]gethrpc.BatchElem, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	elems := builder.Build()
+	for i := range elems {
+		call := elems[i].Args[0].(starknetrpc.FunctionCall)
+		var method string
+		switch call.EntryPointSelector.String() {
+		case starknetutils.GetSelectorFromNameFelt("latest_config_details").String():
+			method = "latest_config_details"
+		case starknetutils.GetSelectorFromNameFelt("latest_transmission_details").String():
+			method = "latest_transmission_details"
+		}
+		res, ok := f.results[call.ContractAddress.String()+method]
+		if !ok {
+			elems[i].Error = errors.New("contract not found")
+			continue
+		}
+		*elems[i].Result.(*[]*felt.Felt) = res
+	}
+	f.batches = append(f.batches, elems)
+	return elems, nil
+}
+
+func TestStateCache_Refresh(t *testing.T) {
+	ctx := tests.Context(t)
+	client := &fakeChainClient{blockNumber: 10, results: map[string][]*felt.Felt{}}
+	cache := NewStateCache(testCacheConfig{}, func() (starknet.ChainClient, error) { return client, nil }, logger.Test(t))
+
+	a, b := new(felt.Felt).SetUint64(0xa), new(felt.Felt).SetUint64(0xb)
+	client.set(a, "latest_config_details", 1, 5, 0x11)
+	client.set(a, "latest_transmission_details", 0x11, 0x101, 42, 1000)
+	client.set(b, "latest_config_details", 1, 7, 0x22)
+	client.set(b, "latest_transmission_details", 0x22, 0x201, 0, 2000)
+	// -10 as an i128 answer
+	client.results[b.String()+"latest_transmission_details"][2], _ = new(felt.Felt).SetString("0xfffffffffffffffffffffffffffffff6")
+
+	unregisterA := cache.Register(a)
+	unregisterB := cache.Register(b)
+	readerA := cache.NewReader(a, nil, false)
+	readerB := cache.NewReader(b, nil, true)
+
+	_, err := readerA.LatestBlockHeight(ctx)
+	require.ErrorContains(t, err, "not yet initialized")
+
+	refreshed := cache.Refreshed(a)
+	require.NoError(t, cache.refresh(ctx))
+	assert.NotNil(t, refreshed)
+	select {
+	case <-refreshed:
+	default:
+		t.Fatal("expected the refresh to be signalled")
+	}
+	assert.NotEqual(t, refreshed, cache.Refreshed(a))
+	require.Len(t, client.batches, 1)
+	require.Len(t, client.batches[0], 4)
+	for _, elem := range client.batches[0] {
+		assert.Equal(t, starknetrpc.WithBlockNumber(10), elem.Args[1])
+	}
+
+	blockHeight, err := readerA.LatestBlockHeight(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), blockHeight)
+
+	configBlock, digest, err := readerA.LatestConfigDetails(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), configBlock)
+	assert.Equal(t, byte(0x11), digest[31])
+
+	_, epoch, round, answer, _, err := readerA.LatestTransmissionDetails(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), epoch)
+	assert.Equal(t, uint8(1), round)
+	assert.Equal(t, big.NewInt(42), answer)
+
+	_, _, _, answer, _, err = readerB.LatestTransmissionDetails(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(-10), answer)
+
+	t.Run("failed calls keep the previous state", func(t *testing.T) {
+		client.set(b, "latest_config_details")
+		client.blockNumber = 13
+		require.NoError(t, cache.refresh(ctx))
+
+		configBlock, _, err := readerB.LatestConfigDetails(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), configBlock)
+
+		// b keeps failing until its state expires, while a is still refreshed
+		cache.lock.Lock()
+		cache.contracts[b.String()].checkedAt = time.Now().Add(-2 * time.Hour)
+		cache.lock.Unlock()
+		require.NoError(t, cache.refresh(ctx))
+		_, _, err = readerB.LatestConfigDetails(ctx)
+		require.ErrorContains(t, err, "contract state expired")
+		_, _, err = readerA.LatestConfigDetails(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("unregistered contracts are no longer refreshed", func(t *testing.T) {
+		unregisterB()
+		unregisterB() // no-op
+		require.NoError(t, cache.refresh(ctx))
+		assert.Len(t, client.batches[len(client.batches)-1], 2)
+
+		_, err := readerB.LatestBlockHeight(ctx)
+		require.ErrorContains(t, err, "is not registered")
+		assert.Nil(t, cache.Refreshed(b))
+
+		unregisterA()
+		require.NoError(t, cache.refresh(ctx))
+		assert.Len(t, client.batches, 4)
+	})
+}
+
+func TestStateCache_Batching(t *testing.T) {
+	ctx := tests.Context(t)
+	client := &fakeChainClient{blockNumber: 1, results: map[string][]*felt.Felt{}}
+	cache := NewStateCache(testCacheConfig{}, func() (starknet.ChainClient, error) { return client, nil }, logger.Test(t))
+
+	for i := 1; i <= 60; i++ {
+		address := new(felt.Felt).SetUint64(uint64(i))
+		client.set(address, "latest_config_details", 1, 1, uint64(i))
+		client.set(address, "latest_transmission_details", uint64(i), 0, 0, 0)
+		cache.Register(address)
+	}
+
+	require.NoError(t, cache.refresh(ctx))
+	require.Len(t, client.batches, 2)
+	assert.Len(t, client.batches[0], maxBatchCalls)
+	assert.Len(t, client.batches[1], 20)
+}
+
+func TestStateCache_Client(t *testing.T) {
+	ctx := tests.Context(t)
+	client := &fakeChainClient{blockNumber: 1, results: map[string][]*felt.Felt{}}
+	var clients int
+	cache := NewStateCache(testCacheConfig{}, func() (starknet.ChainClient, error) {
+		clients++
+		return client, nil
+	}, logger.Test(t))
+
+	// the client is reused across polls
+	for i := 0; i < 3; i++ {
+		require.NoError(t, cache.refresh(ctx))
+	}
+	assert.Equal(t, 1, clients)
+
+	// and replaced after a failed poll
+	client.headErr = errors.New("node down")
+	require.Error(t, cache.refresh(ctx))
+	client.headErr = nil
+	require.NoError(t, cache.refresh(ctx))
+	assert.Equal(t, 2, clients)
+}
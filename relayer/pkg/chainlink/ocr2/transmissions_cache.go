@@ -23,6 +23,8 @@ type transmissionsCache struct {
 	tdLock              sync.RWMutex
 	tdLastCheckedAt     time.Time
 
+	// refreshed returns the channel closed on the next refresh of the shared state the reader is served from
+	refreshed  func() <-chan struct{}
 	stop, done chan struct{}
 
 	reader Reader
@@ -30,19 +32,22 @@ type transmissionsCache struct {
 	lggr   logger.Logger
 }
 
-func NewTransmissionsCache(cfg Config, reader Reader, lggr logger.Logger) *transmissionsCache {
+func NewTransmissionsCache(cfg Config, reader Reader, refreshed func() <-chan struct{}, lggr logger.Logger) *transmissionsCache {
 	return &transmissionsCache{
-		cfg:    cfg,
-		reader: reader,
-		lggr:   lggr,
-		stop:   make(chan struct{}),
-		done:   make(chan struct{}),
+		cfg:       cfg,
+		reader:    reader,
+		refreshed: refreshed,
+		lggr:      lggr,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
 		transmissionDetails: TransmissionDetails{
 			LatestAnswer: big.NewInt(0), // should always return at least 0 and not nil
 		},
 	}
 }
 
+// updateTransmission copies the transmission details from the shared state the reader is served from, see
+// NewConfigProvider
 func (c *transmissionsCache) updateTransmission(ctx context.Context) error {
 	digest, epoch, round, answer, timestamp, err := c.reader.LatestTransmissionDetails(ctx)
 	if err != nil {
@@ -68,10 +73,12 @@ func (c *transmissionsCache) updateTransmission(ctx context.Context) error {
 func (c *transmissionsCache) Start() error {
 	ctx, cancel := utils.ContextFromChan(c.stop)
 	defer cancel()
+	// subscribe before the update so a refresh during it isn't missed
+	refreshed := c.refreshed()
 	if err := c.updateTransmission(ctx); err != nil {
 		c.lggr.Warnf("failed to populate initial transmission details: %v", err)
 	}
-	go c.poll()
+	go c.poll(refreshed)
 	return nil
 }
 
@@ -80,22 +87,20 @@ func (c *transmissionsCache) Close() error {
 	return nil
 }
 
-func (c *transmissionsCache) poll() {
+func (c *transmissionsCache) poll(refreshed <-chan struct{}) {
 	defer close(c.done)
-	tick := time.After(0)
 	for {
 		select {
 		case <-c.stop:
 			return
-		case <-tick:
+		case <-refreshed:
+			refreshed = c.refreshed()
 			ctx, cancel := utils.ContextFromChan(c.stop)
 
 			if err := c.updateTransmission(ctx); err != nil {
 				c.lggr.Errorf("Failed to update transmission: %v", err)
 			}
 			cancel()
-
-			tick = time.After(utils.WithJitter(c.cfg.OCR2CachePollPeriod()))
 		}
 	}
 }
@@ -0,0 +1,120 @@
+package ocr2
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+)
+
+// TransmitSignature is a single signature of the 'transmit' call
+type TransmitSignature struct {
+	R         *felt.Felt
+	S         *felt.Felt
+	PublicKey *felt.Felt
+}
+
+// TransmitArgs are the decoded arguments of the aggregator's 'transmit' function
+type TransmitArgs struct {
+	ConfigDigest types.ConfigDigest
+	Epoch        uint32
+	Round        uint8
+	ExtraHash    *felt.Felt
+	Report       types.Report
+	Signatures   []TransmitSignature
+}
+
+// ReportContext rebuilds the libocr report context, e.g. to verify the signatures with VerifyReportSignatures
+func (a TransmitArgs) ReportContext() types.ReportContext {
+	return types.ReportContext{
+		ReportTimestamp: types.ReportTimestamp{
+			ConfigDigest: a.ConfigDigest,
+			Epoch:        a.Epoch,
+			Round:        a.Round,
+		},
+		ExtraHash: a.ExtraHash.Bytes(),
+	}
+}
+
+// ParseTransmitCalldata is the reverse of the payload built by the contractTransmitter:
+// report_context (config_digest, epoch_and_round, extra_hash), report felts, signatures_len, (r, s, public_key)*
+func ParseTransmitCalldata(calldata []*felt.Felt) (TransmitArgs, error) {
+	const reportContextLen = 3
+	const observationsLenIndex = reportContextLen + 2
+	if len(calldata) <= observationsLenIndex {
+		return TransmitArgs{}, errors.New("invalid: transmit calldata")
+	}
+
+	// report: timestamp, observers, observations_len, observations, juels_per_fee_coin, gas_price
+	observationsLen := calldata[observationsLenIndex].BigInt(big.NewInt(0))
+	if !observationsLen.IsInt64() || observationsLen.Int64() > int64(len(calldata)) {
+		return TransmitArgs{}, fmt.Errorf("invalid number of observations: %v", observationsLen)
+	}
+	reportEnd := observationsLenIndex + 1 + int(observationsLen.Int64()) + 2
+	if len(calldata) <= reportEnd {
+		return TransmitArgs{}, errors.New("invalid: transmit calldata")
+	}
+
+	sigsLen := calldata[reportEnd].BigInt(big.NewInt(0))
+	if !sigsLen.IsInt64() || sigsLen.Int64() > int64(len(calldata)) || len(calldata) != reportEnd+1+3*int(sigsLen.Int64()) {
+		return TransmitArgs{}, fmt.Errorf("invalid transmit calldata length for %v signatures", sigsLen)
+	}
+
+	digest := calldata[0].Bytes()
+	configDigest, err := types.BytesToConfigDigest(digest[:])
+	if err != nil {
+		return TransmitArgs{}, fmt.Errorf("couldn't decode config digest: %w", err)
+	}
+	epoch, round := parseEpochAndRound(calldata[1].BigInt(big.NewInt(0)))
+
+	var report []byte
+	for _, f := range calldata[reportContextLen:reportEnd] {
+		b := f.Bytes()
+		report = append(report, b[:]...)
+	}
+
+	var sigs []TransmitSignature
+	for i := reportEnd + 1; i < len(calldata); i += 3 {
+		sigs = append(sigs, TransmitSignature{
+			R:         calldata[i],
+			S:         calldata[i+1],
+			PublicKey: calldata[i+2],
+		})
+	}
+
+	return TransmitArgs{
+		ConfigDigest: configDigest,
+		Epoch:        epoch,
+		Round:        round,
+		ExtraHash:    calldata[2],
+		Report:       report,
+		Signatures:   sigs,
+	}, nil
+}
+
+// ParseTransmitTransaction finds the 'transmit' call to the aggregator in an invoke transaction and decodes it
+func ParseTransmitTransaction(tx starknetrpc.Transaction, contractAddress *felt.Felt) (TransmitArgs, error) {
+	calldata, err := starknet.InvokeCalldata(tx)
+	if err != nil {
+		return TransmitArgs{}, err
+	}
+	calls, err := starknet.ParseExecuteCalldata(calldata)
+	if err != nil {
+		return TransmitArgs{}, fmt.Errorf("couldn't decode account calldata: %w", err)
+	}
+
+	selector := starknetutils.GetSelectorFromNameFelt("transmit")
+	for _, call := range calls {
+		if call.ContractAddress.Equal(contractAddress) && call.EntryPointSelector.Equal(selector) {
+			return ParseTransmitCalldata(call.Calldata)
+		}
+	}
+	return TransmitArgs{}, fmt.Errorf("no transmit call to %s in transaction", contractAddress)
+}
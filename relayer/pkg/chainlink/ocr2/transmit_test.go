@@ -0,0 +1,112 @@
+package ocr2
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetaccount "github.com/NethermindEth/starknet.go/account"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/median"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2/medianreport"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/txm"
+)
+
+// testAccounts is a single transmitter account (0x2) signing with the public key 0x1
+var testAccounts = []TransmitterAccount{{AccountAddress: new(felt.Felt).SetUint64(2), PublicKey: new(felt.Felt).SetUint64(1)}}
+
+// fakeTxManager records the enqueued calls and their accounts, pending is returned as the unconfirmed calls
+type fakeTxManager struct {
+	calls    []starknetrpc.FunctionCall
+	accounts []*felt.Felt
+	pending  []starknetrpc.FunctionCall
+}
+
+func (f *fakeTxManager) Enqueue(_ context.Context, accountAddress, _ *felt.Felt, call starknetrpc.FunctionCall) error {
+	f.calls = append(f.calls, call)
+	f.accounts = append(f.accounts, accountAddress)
+	return nil
+}
+
+func (f *fakeTxManager) AccountInflightCount(accountAddress *felt.Felt) (queued int, unconfirmed int) {
+	for _, account := range f.accounts {
+		if account.Equal(accountAddress) {
+			unconfirmed++
+		}
+	}
+	return 0, unconfirmed
+}
+
+func (f *fakeTxManager) RegisterAccount(*felt.Felt, txm.AccountStrategy) {}
+
+func (f *fakeTxManager) InflightCount() (int, int) {
+	return 0, len(f.calls)
+}
+
+func (f *fakeTxManager) PendingCalls(contractAddress *felt.Felt) (calls []starknetrpc.FunctionCall) {
+	for _, call := range f.pending {
+		if call.ContractAddress.Equal(contractAddress) {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func TestParseTransmitTransaction(t *testing.T) {
+	ctx := tests.Context(t)
+	report, err := medianreport.ReportCodec{}.BuildReport(ctx, []median.ParsedAttributedObservation{
+		{Timestamp: 1, Value: big.NewInt(10), JuelsPerFeeCoin: big.NewInt(2), GasPriceSubunits: big.NewInt(3), Observer: commontypes.OracleID(1)},
+		{Timestamp: 2, Value: big.NewInt(20), JuelsPerFeeCoin: big.NewInt(2), GasPriceSubunits: big.NewInt(3), Observer: commontypes.OracleID(0)},
+	})
+	require.NoError(t, err)
+
+	reportCtx, _ := testReport(t)
+	signers := newTestSigners(t, 4)
+	config := testConfig(reportCtx, signers)
+	config.F = 0
+
+	txm := &fakeTxManager{}
+	contract := "0x1234"
+	transmitter := NewContractTransmitter(NewTransmissionsCache(testCacheConfig{}, nil, nil, logger.Test(t)), staticConfigTracker{config: config}, contract, testAccounts, SelectRoundRobin, nil, txm, logger.Test(t))
+	sig := signers[0].sign(t, reportCtx, report)
+	require.NoError(t, transmitter.Transmit(ctx, reportCtx, report, []types.AttributedOnchainSignature{{Signature: sig, Signer: 0}}))
+	require.Len(t, txm.calls, 1)
+
+	tx := starknetrpc.InvokeTxnV1{Calldata: starknetaccount.FmtCallDataCairo2(txm.calls)}
+	args, err := ParseTransmitTransaction(tx, transmitter.contractAddress)
+	require.NoError(t, err)
+
+	assert.Equal(t, reportCtx.ConfigDigest, args.ConfigDigest)
+	assert.Equal(t, uint32(7), args.Epoch)
+	assert.Equal(t, uint8(3), args.Round)
+	assert.Equal(t, report, args.Report)
+	expectedSig, err := ParseOnchainSignature(sig)
+	require.NoError(t, err)
+	assert.Equal(t, []TransmitSignature{expectedSig}, args.Signatures)
+	require.NoError(t, VerifyReportSignatures(config, args.ReportContext(), args.Report, args.Signatures))
+
+	_, err = ParseTransmitTransaction(tx, new(felt.Felt).SetUint64(1))
+	assert.ErrorContains(t, err, "no transmit call to 0x1 in transaction")
+
+	calldata := txm.calls[0].Calldata
+	_, err = ParseTransmitCalldata(calldata[:len(calldata)-1])
+	assert.ErrorContains(t, err, "invalid transmit calldata length for 1 signatures")
+	_, err = ParseTransmitCalldata(calldata[:5])
+	assert.ErrorContains(t, err, "invalid: transmit calldata")
+
+	// 3 * signatures_len overflows int
+	overflowing := append([]*felt.Felt{}, calldata...)
+	overflowing[len(calldata)-4] = new(felt.Felt).SetUint64(1<<62 + 1)
+	_, err = ParseTransmitCalldata(overflowing)
+	assert.ErrorContains(t, err, "invalid transmit calldata length")
+}
@@ -0,0 +1,111 @@
+package ocr2
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/txm"
+)
+
+// TransmitterAccount is an account contract sending transmissions, along with the key signing its txs
+type TransmitterAccount struct {
+	AccountAddress *felt.Felt
+	PublicKey      *felt.Felt
+}
+
+// TransmitterSelection is how the transmitter picks the account for the next transmission
+type TransmitterSelection string
+
+const (
+	SelectRoundRobin  TransmitterSelection = "roundRobin"
+	SelectLeastLoaded TransmitterSelection = "leastLoaded"
+)
+
+// ParseTransmitterSelection validates a selection strategy, defaulting to round robin
+func ParseTransmitterSelection(s string) (TransmitterSelection, error) {
+	switch selection := TransmitterSelection(s); selection {
+	case "":
+		return SelectRoundRobin, nil
+	case SelectRoundRobin, SelectLeastLoaded:
+		return selection, nil
+	default:
+		return "", fmt.Errorf("unknown transmitter selection %q", s)
+	}
+}
+
+// transmitterPool spreads the transmissions of a feed over several accounts so a single nonce, or a single stuck tx,
+// doesn't limit the feed.
+// The aggregator reimburses and pays the account that sent the transmission, so every account has to be in its
+// transmitters list and billing is attributed per sending account, not to the primary account. The transmissions
+// sent by each account are counted in starknet_ocr2_transmissions_sent.
+type transmitterPool struct {
+	accounts  []TransmitterAccount
+	selection TransmitterSelection
+	txm       txm.TxManager
+
+	lock sync.Mutex
+	next int
+}
+
+func newTransmitterPool(accounts []TransmitterAccount, selection TransmitterSelection, txm txm.TxManager) *transmitterPool {
+	return &transmitterPool{
+		accounts:  accounts,
+		selection: selection,
+		txm:       txm,
+	}
+}
+
+// primary is the account set for the job, it identifies the node as a transmitter to libocr
+func (p *transmitterPool) primary() TransmitterAccount {
+	return p.accounts[0]
+}
+
+// pick returns the account for the next transmission. Only accounts in registered are used, unless none of them are,
+// e.g. when the config isn't available yet.
+func (p *transmitterPool) pick(registered []types.Account) TransmitterAccount {
+	if len(p.accounts) == 1 {
+		return p.accounts[0]
+	}
+
+	candidates := p.registered(registered)
+	if len(candidates) == 0 {
+		candidates = p.accounts
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	start := p.next % len(candidates)
+	p.next++
+
+	if p.selection != SelectLeastLoaded {
+		return candidates[start]
+	}
+	// ties go to the round robin order so idle accounts are still all used
+	best, bestLoad := candidates[start], -1
+	for i := range candidates {
+		account := candidates[(start+i)%len(candidates)]
+		queued, unconfirmed := p.txm.AccountInflightCount(account.AccountAddress)
+		if load := queued + unconfirmed; bestLoad < 0 || load < bestLoad {
+			best, bestLoad = account, load
+		}
+	}
+	return best
+}
+
+func (p *transmitterPool) registered(transmitters []types.Account) (accounts []TransmitterAccount) {
+	for _, account := range p.accounts {
+		for _, transmitter := range transmitters {
+			address, err := starknetutils.HexToFelt(string(transmitter))
+			if err == nil && address.Equal(account.AccountAddress) {
+				accounts = append(accounts, account)
+				break
+			}
+		}
+	}
+	return accounts
+}
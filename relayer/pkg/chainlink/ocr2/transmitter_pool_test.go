@@ -0,0 +1,107 @@
+package ocr2
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+var testCall = starknetrpc.FunctionCall{ContractAddress: new(felt.Felt).SetUint64(0x1234)}
+
+func newTestAccounts(addresses ...uint64) (accounts []TransmitterAccount) {
+	for _, address := range addresses {
+		accounts = append(accounts, TransmitterAccount{AccountAddress: new(felt.Felt).SetUint64(address), PublicKey: new(felt.Felt).SetUint64(1)})
+	}
+	return accounts
+}
+
+func TestTransmitterPool(t *testing.T) {
+	accounts := newTestAccounts(0xa, 0xb, 0xc)
+	picked := func(pool *transmitterPool, registered []types.Account, n int) (addresses []string) {
+		for i := 0; i < n; i++ {
+			account := pool.pick(registered)
+			addresses = append(addresses, account.AccountAddress.String())
+			_ = pool.txm.Enqueue(tests.Context(t), account.AccountAddress, account.PublicKey, testCall)
+		}
+		return addresses
+	}
+
+	t.Run("round robin", func(t *testing.T) {
+		pool := newTransmitterPool(accounts, SelectRoundRobin, &fakeTxManager{})
+		assert.Equal(t, []string{"0xa", "0xb", "0xc", "0xa"}, picked(pool, nil, 4))
+	})
+
+	t.Run("only registered accounts", func(t *testing.T) {
+		pool := newTransmitterPool(accounts, SelectRoundRobin, &fakeTxManager{})
+		registered := []types.Account{"0x000a", "0xc", "0xd"}
+		assert.Equal(t, []string{"0xa", "0xc", "0xa"}, picked(pool, registered, 3))
+
+		// none of the accounts are registered, e.g. config not set yet
+		assert.Len(t, picked(pool, []types.Account{"0xd"}, 3), 3)
+	})
+
+	t.Run("least loaded", func(t *testing.T) {
+		txm := &fakeTxManager{}
+		pool := newTransmitterPool(accounts, SelectLeastLoaded, txm)
+		for i := 0; i < 3; i++ {
+			_ = txm.Enqueue(tests.Context(t), accounts[0].AccountAddress, accounts[0].PublicKey, testCall)
+		}
+		_ = txm.Enqueue(tests.Context(t), accounts[2].AccountAddress, accounts[2].PublicKey, testCall)
+
+		// 0xb is idle, 0xc catches up with it and then they alternate
+		assert.Equal(t, []string{"0xb", "0xb", "0xc", "0xb", "0xc"}, picked(pool, nil, 5))
+	})
+
+	t.Run("single account", func(t *testing.T) {
+		pool := newTransmitterPool(accounts[:1], SelectLeastLoaded, &fakeTxManager{})
+		assert.Equal(t, []string{"0xa", "0xa"}, picked(pool, []types.Account{"0xd"}, 2))
+	})
+}
+
+func TestContractTransmitter_TransmitterAccounts(t *testing.T) {
+	ctx := tests.Context(t)
+	reportCtx, report := testReport(t)
+	signers := newTestSigners(t, 4)
+	config := testConfig(reportCtx, signers)
+	config.Transmitters = []types.Account{"0xa", "0xb", "0xc", "0xd"}
+	sigs := []types.AttributedOnchainSignature{
+		{Signature: signers[0].sign(t, reportCtx, report), Signer: 0},
+		{Signature: signers[1].sign(t, reportCtx, report), Signer: 1},
+	}
+
+	txm := &fakeTxManager{}
+	// 0xe isn't registered on the aggregator
+	accounts := newTestAccounts(0xa, 0xe, 0xb)
+	cache := NewTransmissionsCache(testCacheConfig{}, nil, nil, logger.Test(t))
+	transmitter := NewContractTransmitter(cache, staticConfigTracker{config: config}, "0x1234", accounts, SelectRoundRobin, nil, txm, logger.Test(t))
+
+	sent := func(account string) float64 {
+		return testutil.ToFloat64(promTransmissionsSent.WithLabelValues(transmitter.contractAddress.String(), account))
+	}
+	sentA, sentB := sent("0xa"), sent("0xb")
+	for i := 0; i < 4; i++ {
+		require.NoError(t, transmitter.Transmit(ctx, reportCtx, report, sigs))
+	}
+	var used []string
+	for _, account := range txm.accounts {
+		used = append(used, account.String())
+	}
+	assert.Equal(t, []string{"0xa", "0xb", "0xa", "0xb"}, used)
+	// billing is attributed to the account that sent the transmission
+	assert.Equal(t, 2.0, sent("0xa")-sentA)
+	assert.Equal(t, 2.0, sent("0xb")-sentB)
+	assert.Equal(t, 0.0, sent("0xe"))
+
+	from, err := transmitter.FromAccount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Account("0xa"), from)
+}
@@ -27,6 +27,13 @@ type ContractConfig struct {
 	ConfigBlock uint64
 }
 
+// ConfigHistoryEntry is a single config set on the aggregator, along with the block of the config it replaced
+type ConfigHistoryEntry struct {
+	ContractConfig
+	// PreviousConfigBlock is 0 for the first config set on the contract
+	PreviousConfigBlock uint64
+}
+
 type TransmissionDetails struct {
 	Digest          types.ConfigDigest
 	Epoch           uint32
@@ -35,15 +42,27 @@ type TransmissionDetails struct {
 	LatestTimestamp time.Time
 }
 
+// BillingDetails is the aggregator's BillingConfig
 type BillingDetails struct {
-	ObservationPaymentGJuels  uint64
-	TransmissionPaymentGJuels uint64
+	ObservationPaymentGJuels  uint32
+	TransmissionPaymentGJuels uint32
+	GasBase                   uint32
+	GasPerSignature           uint32
 }
 
-func NewBillingDetails(observationPaymentGJuels *big.Int, transmissionPaymentGJuels *big.Int) (bd BillingDetails, err error) {
+func NewBillingDetails(observationPaymentGJuels, transmissionPaymentGJuels, gasBase, gasPerSignature *big.Int) (bd BillingDetails, err error) {
+	values := []*big.Int{observationPaymentGJuels, transmissionPaymentGJuels, gasBase, gasPerSignature}
+	names := []string{"observation payment", "transmission payment", "gas base", "gas per signature"}
+	for i, v := range values {
+		if v.Sign() < 0 || !v.IsUint64() || v.Uint64() > math.MaxUint32 {
+			return bd, fmt.Errorf("%s '%s' does not fit into uint32", names[i], v)
+		}
+	}
 	return BillingDetails{
-		ObservationPaymentGJuels:  observationPaymentGJuels.Uint64(),
-		TransmissionPaymentGJuels: transmissionPaymentGJuels.Uint64(),
+		ObservationPaymentGJuels:  uint32(observationPaymentGJuels.Uint64()),
+		TransmissionPaymentGJuels: uint32(transmissionPaymentGJuels.Uint64()),
+		GasBase:                   uint32(gasBase.Uint64()),
+		GasPerSignature:           uint32(gasPerSignature.Uint64()),
 	}, nil
 }
 
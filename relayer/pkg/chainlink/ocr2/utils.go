@@ -17,6 +17,11 @@ func parseEpochAndRound(felt *big.Int) (epoch uint32, round uint8) {
 	return epoch, round
 }
 
+// packEpochAndRound returns the epoch_and_round value the aggregator compares transmissions by
+func packEpochAndRound(epoch uint32, round uint8) uint64 {
+	return uint64(epoch)<<8 | uint64(round)
+}
+
 /* Testing utils - do not use (XXX) outside testing context */
 
 func XXXMustBytesToConfigDigest(b []byte) types.ConfigDigest {
@@ -0,0 +1,205 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+)
+
+// phaseShift splits proxy round ids into the phase id (high 128 bits) and the aggregator round id (low 128 bits)
+const phaseShift = 128
+
+//go:generate mockery --name ProxyReader --output ./mocks/
+type ProxyReader interface { //nolint:revive
+	LatestRoundData(context.Context, *felt.Felt) (RoundData, error)
+	RoundData(context.Context, *felt.Felt, *big.Int) (RoundData, error)
+	ProposedLatestRoundData(context.Context, *felt.Felt) (ocr2.RoundData, error)
+	Aggregator(context.Context, *felt.Felt) (*felt.Felt, error)
+	ProposedAggregator(context.Context, *felt.Felt) (*felt.Felt, error)
+	PhaseID(context.Context, *felt.Felt) (uint64, error)
+	ResolveRound(context.Context, *felt.Felt, *big.Int) (ResolvedRound, error)
+
+	BaseReader() starknet.Reader
+}
+
+var _ ProxyReader = (*Client)(nil)
+
+// RoundData is a round as returned by the proxy, its round id is prefixed with the phase of the aggregator
+type RoundData struct {
+	ocr2.RoundData
+	PhaseID uint64
+	// ProxyRoundID is the phase aware round id, the embedded RoundID is the round id of the aggregator
+	ProxyRoundID *big.Int
+}
+
+// ResolvedRound is a proxy round together with the aggregator that reported it
+type ResolvedRound struct {
+	RoundData
+	// Aggregator is only known for the current phase, the proxy does not expose the aggregators of past phases
+	Aggregator *felt.Felt
+}
+
+// ProxyRoundID builds the round id the proxy returns for a round of the aggregator of the given phase
+func ProxyRoundID(phaseID uint64, aggregatorRoundID uint32) *big.Int {
+	id := new(big.Int).Lsh(new(big.Int).SetUint64(phaseID), phaseShift)
+	return id.Or(id, new(big.Int).SetUint64(uint64(aggregatorRoundID)))
+}
+
+// SplitRoundID is the inverse of ProxyRoundID
+func SplitRoundID(roundID *big.Int) (phaseID uint64, aggregatorRoundID uint32, err error) {
+	phase := new(big.Int).Rsh(roundID, phaseShift)
+	if !phase.IsUint64() {
+		return 0, 0, fmt.Errorf("phase id of round '%s' does not fit in a uint64", roundID)
+	}
+	round := new(big.Int).Sub(roundID, new(big.Int).Lsh(phase, phaseShift))
+	if !round.IsUint64() || round.Uint64() > math.MaxUint32 {
+		return 0, 0, fmt.Errorf("aggregator round id of round '%s' does not fit in a uint32", roundID)
+	}
+	return phase.Uint64(), uint32(round.Uint64()), nil
+}
+
+type Client struct {
+	r    starknet.Reader
+	lggr logger.Logger
+}
+
+func NewClient(reader starknet.Reader, lggr logger.Logger) (*Client, error) {
+	return &Client{
+		r:    reader,
+		lggr: lggr,
+	}, nil
+}
+
+func (c *Client) BaseReader() starknet.Reader {
+	return c.r
+}
+
+func (c *Client) LatestRoundData(ctx context.Context, address *felt.Felt) (round RoundData, err error) {
+	felts, err := c.r.CallContract(ctx, starknet.CallOps{
+		ContractAddress: address,
+		Selector:        starknetutils.GetSelectorFromNameFelt("latest_round_data"),
+	})
+	if err != nil {
+		return round, fmt.Errorf("couldn't call the contract with selector latest_round_data: %w", err)
+	}
+	return newRoundData(felts)
+}
+
+func (c *Client) RoundData(ctx context.Context, address *felt.Felt, roundID *big.Int) (round RoundData, err error) {
+	felts, err := c.r.CallContract(ctx, starknet.CallOps{
+		ContractAddress: address,
+		Selector:        starknetutils.GetSelectorFromNameFelt("round_data"),
+		Calldata:        []*felt.Felt{starknetutils.BigIntToFelt(roundID)},
+	})
+	if err != nil {
+		return round, fmt.Errorf("couldn't call the contract with selector round_data: %w", err)
+	}
+	return newRoundData(felts)
+}
+
+// ProposedLatestRoundData reads the latest round of the proposed aggregator, its round id is not phase aware
+func (c *Client) ProposedLatestRoundData(ctx context.Context, address *felt.Felt) (round ocr2.RoundData, err error) {
+	felts, err := c.r.CallContract(ctx, starknet.CallOps{
+		ContractAddress: address,
+		Selector:        starknetutils.GetSelectorFromNameFelt("proposed_latest_round_data"),
+	})
+	if err != nil {
+		return round, fmt.Errorf("couldn't call the contract with selector proposed_latest_round_data: %w", err)
+	}
+	round, err = ocr2.NewRoundData(felts)
+	if err != nil {
+		return round, fmt.Errorf("unable to decode RoundData: %w", err)
+	}
+	return round, nil
+}
+
+func (c *Client) Aggregator(ctx context.Context, address *felt.Felt) (*felt.Felt, error) {
+	res, err := c.r.CallContract(ctx, starknet.CallOps{
+		ContractAddress: address,
+		Selector:        starknetutils.GetSelectorFromNameFelt("aggregator"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call the contract with selector aggregator: %w", err)
+	}
+	if len(res) != 1 {
+		return nil, fmt.Errorf("unexpected data returned from aggregator: %v", res)
+	}
+	return res[0], nil
+}
+
+// ProposedAggregator returns the aggregator waiting to be confirmed, zero if there is none
+func (c *Client) ProposedAggregator(ctx context.Context, address *felt.Felt) (*felt.Felt, error) {
+	// the proposed aggregator has no getter
+	proposed, err := c.r.StorageAt(ctx, address, "_proposed_aggregator")
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the proposed aggregator: %w", err)
+	}
+	return proposed, nil
+}
+
+func (c *Client) PhaseID(ctx context.Context, address *felt.Felt) (uint64, error) {
+	res, err := c.r.CallContract(ctx, starknet.CallOps{
+		ContractAddress: address,
+		Selector:        starknetutils.GetSelectorFromNameFelt("phase_id"),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("couldn't call the contract with selector phase_id: %w", err)
+	}
+	if len(res) != 1 {
+		return 0, fmt.Errorf("unexpected data returned from phase_id: %v", res)
+	}
+	phaseID := res[0].BigInt(big.NewInt(0))
+	if !phaseID.IsUint64() {
+		return 0, fmt.Errorf("phase id '%s' does not fit in a uint64", phaseID)
+	}
+	return phaseID.Uint64(), nil
+}
+
+// ResolveRound reads a proxy round and, if it belongs to the current phase, the aggregator that reported it
+func (c *Client) ResolveRound(ctx context.Context, address *felt.Felt, roundID *big.Int) (resolved ResolvedRound, err error) {
+	if _, _, err = SplitRoundID(roundID); err != nil {
+		return resolved, err
+	}
+	resolved.RoundData, err = c.RoundData(ctx, address, roundID)
+	if err != nil {
+		return resolved, err
+	}
+	phaseID, err := c.PhaseID(ctx, address)
+	if err != nil {
+		return resolved, err
+	}
+	if phaseID != resolved.PhaseID {
+		return resolved, nil
+	}
+	resolved.Aggregator, err = c.Aggregator(ctx, address)
+	return resolved, err
+}
+
+func newRoundData(felts []*felt.Felt) (round RoundData, err error) {
+	if len(felts) == 0 {
+		return round, fmt.Errorf("expected number of felts to be 5 but got %d", len(felts))
+	}
+	round.ProxyRoundID = felts[0].BigInt(big.NewInt(0))
+	phaseID, aggregatorRoundID, err := SplitRoundID(round.ProxyRoundID)
+	if err != nil {
+		return round, err
+	}
+
+	// decode the rest as an aggregator round
+	aggregatorRound := append([]*felt.Felt{new(felt.Felt).SetUint64(uint64(aggregatorRoundID))}, felts[1:]...)
+	round.RoundData, err = ocr2.NewRoundData(aggregatorRound)
+	if err != nil {
+		return round, fmt.Errorf("unable to decode RoundData: %w", err)
+	}
+	round.PhaseID = phaseID
+	return round, nil
+}
@@ -0,0 +1,110 @@
+package proxy
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet/mocks"
+)
+
+var (
+	proxyAddress      = new(felt.Felt).SetUint64(0x1234)
+	aggregatorAddress = new(felt.Felt).SetUint64(0x5678)
+)
+
+func callTo(selector string, calldata ...*felt.Felt) interface{} {
+	return mock.MatchedBy(func(ops starknet.CallOps) bool {
+		if !ops.ContractAddress.Equal(proxyAddress) || !ops.Selector.Equal(starknetutils.GetSelectorFromNameFelt(selector)) {
+			return false
+		}
+		return assert.ObjectsAreEqual(calldata, ops.Calldata) || (len(calldata) == 0 && len(ops.Calldata) == 0)
+	})
+}
+
+func round(roundID *big.Int, answer uint64) []*felt.Felt {
+	return []*felt.Felt{
+		starknetutils.BigIntToFelt(roundID),
+		new(felt.Felt).SetUint64(answer),
+		new(felt.Felt).SetUint64(100),  // block_num
+		new(felt.Felt).SetUint64(1000), // started_at
+		new(felt.Felt).SetUint64(1060), // updated_at
+	}
+}
+
+func TestRoundID(t *testing.T) {
+	id := ProxyRoundID(2, 7)
+	assert.Equal(t, "680564733841876926926749214863536422919", id.String()) // 2 << 128 | 7
+
+	phaseID, roundID, err := SplitRoundID(id)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), phaseID)
+	assert.Equal(t, uint32(7), roundID)
+
+	_, _, err = SplitRoundID(new(big.Int).Lsh(big.NewInt(1), 40))
+	assert.ErrorContains(t, err, "does not fit in a uint32")
+}
+
+func TestClient(t *testing.T) {
+	ctx := tests.Context(t)
+	reader := mocks.NewReader(t)
+	client, err := NewClient(reader, logger.Test(t))
+	require.NoError(t, err)
+
+	t.Run("latest round data", func(t *testing.T) {
+		reader.On("CallContract", mock.Anything, callTo("latest_round_data")).Return(round(ProxyRoundID(2, 7), 42), nil).Once()
+		data, err := client.LatestRoundData(ctx, proxyAddress)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), data.PhaseID)
+		assert.Equal(t, uint32(7), data.RoundID)
+		assert.Equal(t, ProxyRoundID(2, 7), data.ProxyRoundID)
+		assert.Equal(t, big.NewInt(42), data.Answer)
+	})
+
+	t.Run("aggregators", func(t *testing.T) {
+		reader.On("CallContract", mock.Anything, callTo("aggregator")).Return([]*felt.Felt{aggregatorAddress}, nil).Once()
+		aggregator, err := client.Aggregator(ctx, proxyAddress)
+		require.NoError(t, err)
+		assert.Equal(t, aggregatorAddress, aggregator)
+
+		reader.On("StorageAt", mock.Anything, proxyAddress, "_proposed_aggregator").Return(&felt.Zero, nil).Once()
+		proposed, err := client.ProposedAggregator(ctx, proxyAddress)
+		require.NoError(t, err)
+		assert.True(t, proposed.IsZero())
+
+		reader.On("CallContract", mock.Anything, callTo("proposed_latest_round_data")).Return(round(big.NewInt(3), 40), nil).Once()
+		proposedRound, err := client.ProposedLatestRoundData(ctx, proxyAddress)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(3), proposedRound.RoundID)
+	})
+
+	t.Run("resolve round", func(t *testing.T) {
+		current := ProxyRoundID(2, 7)
+		reader.On("CallContract", mock.Anything, callTo("round_data", starknetutils.BigIntToFelt(current))).Return(round(current, 42), nil).Once()
+		reader.On("CallContract", mock.Anything, callTo("phase_id")).Return([]*felt.Felt{new(felt.Felt).SetUint64(2)}, nil).Once()
+		reader.On("CallContract", mock.Anything, callTo("aggregator")).Return([]*felt.Felt{aggregatorAddress}, nil).Once()
+		resolved, err := client.ResolveRound(ctx, proxyAddress, current)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(7), resolved.RoundID)
+		assert.Equal(t, aggregatorAddress, resolved.Aggregator)
+
+		// the aggregators of past phases are unknown
+		past := ProxyRoundID(1, 9)
+		reader.On("CallContract", mock.Anything, callTo("round_data", starknetutils.BigIntToFelt(past))).Return(round(past, 41), nil).Once()
+		reader.On("CallContract", mock.Anything, callTo("phase_id")).Return([]*felt.Felt{new(felt.Felt).SetUint64(2)}, nil).Once()
+		resolved, err = client.ResolveRound(ctx, proxyAddress, past)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), resolved.PhaseID)
+		assert.Equal(t, uint32(9), resolved.RoundID)
+		assert.Nil(t, resolved.Aggregator)
+	})
+}
@@ -7,6 +7,9 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/NethermindEth/juno/core/felt"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/services"
 	relaytypes "github.com/smartcontractkit/chainlink-common/pkg/types"
@@ -14,21 +17,34 @@ import (
 
 	starkchain "github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/chain"
 	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/txm"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/uptimefeed"
 )
 
 var _ relaytypes.Relayer = (*relayer)(nil) //nolint:staticcheck
 
 type relayer struct {
-	chain starkchain.Chain
+	chain      starkchain.Chain
+	stateCache *ocr2.StateCache
+	// nil unless the chain is configured with a sequencer uptime feed
+	sequencer *uptimefeed.StatusTracker
 
 	lggr logger.Logger
 }
 
 func NewRelayer(lggr logger.Logger, chain starkchain.Chain, capRegistry core.CapabilitiesRegistry) *relayer {
-	return &relayer{
-		chain: chain,
-		lggr:  logger.Named(lggr, "Relayer"),
+	lggr = logger.Named(lggr, "Relayer")
+	r := &relayer{
+		chain:      chain,
+		stateCache: ocr2.NewStateCache(chain.Config(), chain.ChainClient, lggr),
+		lggr:       lggr,
+	}
+	if address := chain.Config().SequencerUptimeFeedAddress(); address != "" {
+		// validated with the chain config
+		feed, _ := starknetutils.HexToFelt(address)
+		r.sequencer = uptimefeed.NewStatusTracker(feed, chain.Config().OCR2CachePollPeriod, chain.Reader, lggr)
 	}
+	return r
 }
 
 func (r *relayer) Name() string {
@@ -36,11 +52,23 @@ func (r *relayer) Name() string {
 }
 
 func (r *relayer) Start(ctx context.Context) error {
-	return r.chain.Start(ctx)
+	if err := r.chain.Start(ctx); err != nil {
+		return err
+	}
+	if r.sequencer != nil {
+		if err := r.sequencer.Start(ctx); err != nil {
+			return err
+		}
+	}
+	return r.stateCache.Start(ctx)
 }
 
 func (r *relayer) Close() error {
-	return r.chain.Close()
+	var err error
+	if r.sequencer != nil {
+		err = r.sequencer.Close()
+	}
+	return errors.Join(err, r.stateCache.Close(), r.chain.Close())
 }
 
 func (r *relayer) Ready() error {
@@ -52,9 +80,21 @@ func (r *relayer) Healthy() error { return nil }
 func (r *relayer) HealthReport() map[string]error {
 	hp := map[string]error{r.Name(): r.Healthy()}
 	services.CopyHealth(hp, r.chain.HealthReport())
+	services.CopyHealth(hp, r.stateCache.HealthReport())
+	if r.sequencer != nil {
+		services.CopyHealth(hp, r.sequencer.HealthReport())
+	}
 	return hp
 }
 
+// sequencerStatus avoids handing a typed nil to the transmitters when no uptime feed is configured
+func (r *relayer) sequencerStatus() ocr2.SequencerStatus {
+	if r.sequencer == nil {
+		return nil
+	}
+	return r.sequencer
+}
+
 func (r *relayer) NewChainWriter(_ context.Context, _ []byte) (relaytypes.ChainWriter, error) {
 	return nil, errors.New("chain writer is not supported for starknet")
 }
@@ -79,6 +119,21 @@ func (r *relayer) Transact(ctx context.Context, from, to string, amount *big.Int
 	return r.chain.Transact(ctx, from, to, amount, balanceCheck)
 }
 
+// RotateKey hands a transmitter account over to a new key through the TXM of the chain, while the node keeps
+// transmitting with its other accounts, see [txm.KeyRotator]. Keys are hex encoded public keys of the keystore.
+// The TXM keeps signing with the new key after a restart, the relay config of the jobs should still be updated.
+func (r *relayer) RotateKey(ctx context.Context, accountAddress, currentKey, newKey string) error {
+	var felts [3]*felt.Felt
+	for i, s := range []string{accountAddress, currentKey, newKey} {
+		f, err := starknetutils.HexToFelt(s)
+		if err != nil {
+			return fmt.Errorf("invalid felt %q: %w", s, err)
+		}
+		felts[i] = f
+	}
+	return r.chain.KeyRotator().RotateKey(ctx, felts[0], felts[1], felts[2])
+}
+
 func (r *relayer) NewConfigProvider(ctx context.Context, args relaytypes.RelayArgs) (relaytypes.ConfigProvider, error) {
 	var relayConfig RelayConfig
 
@@ -91,7 +146,7 @@ func (r *relayer) NewConfigProvider(ctx context.Context, args relaytypes.RelayAr
 	if err != nil {
 		return nil, fmt.Errorf("error in NewConfigProvider chain.Reader: %w", err)
 	}
-	configProvider, err := ocr2.NewConfigProvider(r.chain.ID(), args.ContractID, reader, r.chain.Config(), r.lggr)
+	configProvider, err := ocr2.NewConfigProvider(r.chain.ID(), args.ContractID, relayConfig.SignedAnswers, reader, r.stateCache, r.chain.Config(), r.lggr)
 	if err != nil {
 		return nil, fmt.Errorf("coudln't initialize ConfigProvider: %w", err)
 	}
@@ -116,7 +171,18 @@ func (r *relayer) NewMedianProvider(ctx context.Context, rargs relaytypes.RelayA
 	if err != nil {
 		return nil, fmt.Errorf("error in NewMedianProvider chain.Reader: %w", err)
 	}
-	medianProvider, err := ocr2.NewMedianProvider(r.chain.ID(), rargs.ContractID, pargs.TransmitterID, relayConfig.AccountAddress, reader, r.chain.Config(), r.chain.TxManager(), r.lggr)
+	accounts, strategies, err := transmitterAccounts(relayConfig, pargs.TransmitterID)
+	if err != nil {
+		return nil, err
+	}
+	for i, account := range accounts {
+		r.chain.TxManager().RegisterAccount(account.AccountAddress, strategies[i])
+	}
+	selection, err := ocr2.ParseTransmitterSelection(relayConfig.TransmitterSelection)
+	if err != nil {
+		return nil, err
+	}
+	medianProvider, err := ocr2.NewMedianProvider(r.chain.ID(), rargs.ContractID, accounts, selection, relayConfig.SignedAnswers, reader, r.stateCache, r.sequencerStatus(), r.chain.Config(), r.chain.TxManager(), r.lggr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't initilize MedianProvider: %w", err)
 	}
@@ -124,6 +190,78 @@ func (r *relayer) NewMedianProvider(ctx context.Context, rargs relaytypes.RelayA
 	return medianProvider, nil
 }
 
+// transmitterAccounts lists the account from the relay config first, followed by the additional transmitter accounts,
+// along with the strategy building and signing the transactions of each account
+func transmitterAccounts(relayConfig RelayConfig, transmitterID string) ([]ocr2.TransmitterAccount, []txm.AccountStrategy, error) {
+	parseKey := func(key string) (*felt.Felt, error) {
+		publicKey, err := starknetutils.HexToFelt(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key %q: %w", key, err)
+		}
+		return publicKey, nil
+	}
+	parse := func(account TransmitterAccount) (ocr2.TransmitterAccount, txm.AccountStrategy, error) {
+		accountAddress, err := starknetutils.HexToFelt(account.AccountAddress)
+		if err != nil {
+			return ocr2.TransmitterAccount{}, nil, fmt.Errorf("invalid account address %q: %w", account.AccountAddress, err)
+		}
+		if account.PublicKey == "" {
+			account.PublicKey = transmitterID
+		}
+		publicKey, err := parseKey(account.PublicKey)
+		if err != nil {
+			return ocr2.TransmitterAccount{}, nil, err
+		}
+
+		keys := []*felt.Felt{publicKey}
+		switch {
+		case account.AccountType == txm.AccountTypeMultisig:
+			keys = nil
+			for _, signer := range account.SignerPublicKeys {
+				key, err := parseKey(signer)
+				if err != nil {
+					return ocr2.TransmitterAccount{}, nil, err
+				}
+				keys = append(keys, key)
+			}
+		case account.GuardianPublicKey != "":
+			guardian, err := parseKey(account.GuardianPublicKey)
+			if err != nil {
+				return ocr2.TransmitterAccount{}, nil, err
+			}
+			keys = append(keys, guardian)
+		}
+		strategy, err := txm.NewAccountStrategy(account.AccountType, keys)
+		if err != nil {
+			return ocr2.TransmitterAccount{}, nil, fmt.Errorf("invalid account %s: %w", accountAddress, err)
+		}
+		return ocr2.TransmitterAccount{AccountAddress: accountAddress, PublicKey: publicKey}, strategy, nil
+	}
+
+	var accounts []ocr2.TransmitterAccount
+	var strategies []txm.AccountStrategy
+	seen := map[string]bool{}
+	primary := TransmitterAccount{
+		AccountAddress:    relayConfig.AccountAddress,
+		AccountType:       relayConfig.AccountType,
+		GuardianPublicKey: relayConfig.GuardianPublicKey,
+		SignerPublicKeys:  relayConfig.SignerPublicKeys,
+	}
+	for _, account := range append([]TransmitterAccount{primary}, relayConfig.TransmitterAccounts...) {
+		parsed, strategy, err := parse(account)
+		if err != nil {
+			return nil, nil, err
+		}
+		if seen[parsed.AccountAddress.String()] {
+			return nil, nil, fmt.Errorf("duplicate transmitter account %s", parsed.AccountAddress)
+		}
+		seen[parsed.AccountAddress.String()] = true
+		accounts = append(accounts, parsed)
+		strategies = append(strategies, strategy)
+	}
+	return accounts, strategies, nil
+}
+
 func (r *relayer) NewMercuryProvider(ctx context.Context, rargs relaytypes.RelayArgs, pargs relaytypes.PluginArgs) (relaytypes.MercuryProvider, error) {
 	return nil, errors.New("mercury is not supported for starknet")
 }
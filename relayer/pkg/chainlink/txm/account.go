@@ -0,0 +1,147 @@
+package txm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+)
+
+const (
+	AccountTypeOpenZeppelin = "openzeppelin"
+	AccountTypeArgent       = "argent"
+	AccountTypeMultisig     = "multisig"
+)
+
+// AccountStrategy signs for one kind of account contract. The supported accounts all take the Cairo 2 multicall as
+// __execute__ calldata and only differ in the layout of the signature their __validate__ expects.
+type AccountStrategy interface {
+	// Sign signs the transaction hash with the keys of the account
+	Sign(ctx context.Context, ks KeystoreAdapter, hash *felt.Felt) ([]*felt.Felt, error)
+	// PublicKeys lists the keys Sign needs from the keystore
+	PublicKeys() []*felt.Felt
+}
+
+// NewAccountStrategy builds the strategy for an account type, an empty type is an OpenZeppelin account.
+// The keys are the owner followed by the guardian for Argent accounts, and the signers for multisig accounts.
+func NewAccountStrategy(accountType string, publicKeys []*felt.Felt) (AccountStrategy, error) {
+	if len(publicKeys) == 0 {
+		return nil, errors.New("at least one public key is required")
+	}
+	switch accountType {
+	case "", AccountTypeOpenZeppelin:
+		if len(publicKeys) != 1 {
+			return nil, fmt.Errorf("openzeppelin accounts have a single key, got %d", len(publicKeys))
+		}
+		return NewOpenZeppelinAccount(publicKeys[0]), nil
+	case AccountTypeArgent:
+		switch len(publicKeys) {
+		case 1:
+			return NewArgentAccount(publicKeys[0], nil), nil
+		case 2:
+			return NewArgentAccount(publicKeys[0], publicKeys[1]), nil
+		default:
+			return nil, fmt.Errorf("argent accounts have an owner and an optional guardian key, got %d keys", len(publicKeys))
+		}
+	case AccountTypeMultisig:
+		return NewMultisigAccount(publicKeys)
+	default:
+		return nil, fmt.Errorf("unknown account type %q", accountType)
+	}
+}
+
+// signWith signs the hash with a single keystore key, returning the r and s of the signature
+func signWith(ctx context.Context, ks KeystoreAdapter, publicKey, hash *felt.Felt) (r, s *felt.Felt, err error) {
+	rInt, sInt, err := ks.Sign(ctx, publicKey.String(), starknetutils.FeltToBigInt(hash))
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't sign with key %s: %w", publicKey, err)
+	}
+	return starknetutils.BigIntToFelt(rInt), starknetutils.BigIntToFelt(sInt), nil
+}
+
+// openZeppelinAccount is the Cairo 2 OpenZeppelin account, validating a single [r, s] signature of its key
+type openZeppelinAccount struct {
+	publicKey *felt.Felt
+}
+
+func NewOpenZeppelinAccount(publicKey *felt.Felt) AccountStrategy {
+	return &openZeppelinAccount{publicKey: publicKey}
+}
+
+func (a *openZeppelinAccount) Sign(ctx context.Context, ks KeystoreAdapter, hash *felt.Felt) ([]*felt.Felt, error) {
+	r, s, err := signWith(ctx, ks, a.publicKey, hash)
+	if err != nil {
+		return nil, err
+	}
+	return []*felt.Felt{r, s}, nil
+}
+
+func (a *openZeppelinAccount) PublicKeys() []*felt.Felt {
+	return []*felt.Felt{a.publicKey}
+}
+
+// argentAccount is an Argent account, its signature is [owner_r, owner_s] followed by [guardian_r, guardian_s] when
+// the account has a guardian
+type argentAccount struct {
+	owner    *felt.Felt
+	guardian *felt.Felt // optional
+}
+
+func NewArgentAccount(owner, guardian *felt.Felt) AccountStrategy {
+	return &argentAccount{owner: owner, guardian: guardian}
+}
+
+func (a *argentAccount) Sign(ctx context.Context, ks KeystoreAdapter, hash *felt.Felt) ([]*felt.Felt, error) {
+	var signature []*felt.Felt
+	for _, key := range a.PublicKeys() {
+		r, s, err := signWith(ctx, ks, key, hash)
+		if err != nil {
+			return nil, err
+		}
+		signature = append(signature, r, s)
+	}
+	return signature, nil
+}
+
+func (a *argentAccount) PublicKeys() []*felt.Felt {
+	if a.guardian == nil {
+		return []*felt.Felt{a.owner}
+	}
+	return []*felt.Felt{a.owner, a.guardian}
+}
+
+// multisigAccount is an Argent multisig account. Its signature is a [signer, r, s] triple per signer, ordered by
+// signer, and the signers given here have to meet the threshold of the account.
+type multisigAccount struct {
+	signers []*felt.Felt
+}
+
+func NewMultisigAccount(signers []*felt.Felt) (AccountStrategy, error) {
+	sorted := append([]*felt.Felt{}, signers...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) < 0 })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Equal(sorted[i-1]) {
+			return nil, fmt.Errorf("duplicate multisig signer %s", sorted[i])
+		}
+	}
+	return &multisigAccount{signers: sorted}, nil
+}
+
+func (a *multisigAccount) Sign(ctx context.Context, ks KeystoreAdapter, hash *felt.Felt) ([]*felt.Felt, error) {
+	signature := make([]*felt.Felt, 0, 3*len(a.signers))
+	for _, signer := range a.signers {
+		r, s, err := signWith(ctx, ks, signer, hash)
+		if err != nil {
+			return nil, err
+		}
+		signature = append(signature, signer, r, s)
+	}
+	return signature, nil
+}
+
+func (a *multisigAccount) PublicKeys() []*felt.Felt {
+	return a.signers
+}
@@ -0,0 +1,56 @@
+package txm_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adapters "github.com/smartcontractkit/chainlink-common/pkg/loop/adapters/starknet"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/txm"
+)
+
+func TestAccountStrategies(t *testing.T) {
+	// the keystore signs with r = the key and s = the hash, so the layout of the signature shows which key signed where
+	ks := txm.NewKeystoreAdapter(&testLoopKeystore{signFn: func(ctx context.Context, account string, data []byte) ([]byte, error) {
+		sig, err := adapters.SignatureFromBigInts(starknetutils.HexToBN(account), new(big.Int).SetBytes(data))
+		require.NoError(t, err)
+		return sig.Bytes()
+	}})
+	hash := new(felt.Felt).SetUint64(100)
+	key := func(v uint64) *felt.Felt { return new(felt.Felt).SetUint64(v) }
+
+	for _, tc := range []struct {
+		name        string
+		accountType string
+		keys        []*felt.Felt
+		signature   []*felt.Felt
+	}{
+		{"openzeppelin", "", []*felt.Felt{key(1)}, []*felt.Felt{key(1), hash}},
+		{"argent", txm.AccountTypeArgent, []*felt.Felt{key(1)}, []*felt.Felt{key(1), hash}},
+		{"argent with guardian", txm.AccountTypeArgent, []*felt.Felt{key(1), key(2)}, []*felt.Felt{key(1), hash, key(2), hash}},
+		// signers are sorted
+		{"multisig", txm.AccountTypeMultisig, []*felt.Felt{key(3), key(1)}, []*felt.Felt{key(1), key(1), hash, key(3), key(3), hash}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			account, err := txm.NewAccountStrategy(tc.accountType, tc.keys)
+			require.NoError(t, err)
+
+			signature, err := account.Sign(context.Background(), ks, hash)
+			require.NoError(t, err)
+			assert.Equal(t, tc.signature, signature)
+		})
+	}
+
+	_, err := txm.NewAccountStrategy(txm.AccountTypeOpenZeppelin, []*felt.Felt{key(1), key(2)})
+	assert.ErrorContains(t, err, "single key")
+	_, err = txm.NewAccountStrategy(txm.AccountTypeMultisig, []*felt.Felt{key(1), key(1)})
+	assert.ErrorContains(t, err, "duplicate multisig signer")
+	_, err = txm.NewAccountStrategy("braavos", []*felt.Felt{key(1)})
+	assert.ErrorContains(t, err, "unknown account type")
+}
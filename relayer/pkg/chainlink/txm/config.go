@@ -8,4 +8,6 @@ import "time"
 type Config interface {
 	ConfirmationPoll() time.Duration
 	TxTimeout() time.Duration
+	// AccountClassHash is the account class deployed for transmitter accounts that don't exist yet, empty if disabled
+	AccountClassHash() string
 }
@@ -0,0 +1,240 @@
+package txm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetaccount "github.com/NethermindEth/starknet.go/account"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/keystore"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+)
+
+// ErrAccountNotReady is returned for the transactions of an account that is being deployed or waits for funding
+var ErrAccountNotReady = errors.New("account is not deployed yet")
+
+// accountDeployments tracks the DEPLOY_ACCOUNT transactions sent for transmitter accounts that didn't exist yet
+type accountDeployments struct {
+	lock sync.Mutex
+	// deploy transaction hash by account address
+	pending map[string]*felt.Felt
+	// why an account can't transmit yet, reported in the health report until the account is deployed
+	status map[string]error
+}
+
+func newAccountDeployments() *accountDeployments {
+	return &accountDeployments{
+		pending: map[string]*felt.Felt{},
+		status:  map[string]error{},
+	}
+}
+
+func (d *accountDeployments) setStatus(accountAddress *felt.Felt, err error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if err == nil {
+		delete(d.status, accountAddress.String())
+		return
+	}
+	d.status[accountAddress.String()] = err
+}
+
+func (d *accountDeployments) getPending(accountAddress *felt.Felt) *felt.Felt {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.pending[accountAddress.String()]
+}
+
+func (d *accountDeployments) setPending(accountAddress, hash *felt.Felt) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if hash == nil {
+		delete(d.pending, accountAddress.String())
+		return
+	}
+	d.pending[accountAddress.String()] = hash
+}
+
+func (d *accountDeployments) healthReport(name string) map[string]error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	report := map[string]error{}
+	for address, err := range d.status {
+		report[name+".Account."+address] = err
+	}
+	return report
+}
+
+// ensureDeployed deploys the account if it doesn't exist yet and the TXM is configured with an account class hash.
+// Only OpenZeppelin accounts are deployed, with the public key as salt and as the single constructor argument, which
+// is how Gauntlet deploys accounts. The transactions of an account return ErrAccountNotReady while its deployment is
+// pending or its counterfactual address is not funded.
+func (txm *starktxm) ensureDeployed(ctx context.Context, client *starknet.Client, accountAddress, publicKey *felt.Felt) error {
+	if txm.cfg.AccountClassHash() == "" {
+		return nil
+	}
+
+	err := client.Instrument(ctx, "starknet_getClassHashAt", func(ctx context.Context) error {
+		_, err := client.Provider.ClassHashAt(ctx, starknetrpc.BlockID{Tag: "pending"}, accountAddress)
+		return err
+	})
+	if err == nil {
+		if hash := txm.deployments.getPending(accountAddress); hash != nil {
+			txm.lggr.Infow("account deployed", "accountAddress", accountAddress, "txhash", hash)
+			txm.deployments.setPending(accountAddress, nil)
+		}
+		txm.deployments.setStatus(accountAddress, nil)
+		return nil
+	}
+	if !isRPCError(err, starknetrpc.ErrContractNotFound) {
+		return fmt.Errorf("failed to fetch account class hash: %+w", err)
+	}
+
+	if hash := txm.deployments.getPending(accountAddress); hash != nil {
+		var status *starknetrpc.TxnStatusResp
+		statusErr := client.Instrument(ctx, "starknet_getTransactionStatus", func(ctx context.Context) (err error) {
+			status, err = client.Provider.GetTransactionStatus(ctx, hash)
+			return err
+		})
+		if statusErr != nil {
+			return fmt.Errorf("failed to fetch status of account deployment %s: %+w", hash, statusErr)
+		}
+		if status.FinalityStatus != starknetrpc.TxnStatus_Rejected && status.ExecutionStatus != starknetrpc.TxnExecutionStatusREVERTED {
+			return fmt.Errorf("%w: deployment %s is %s", ErrAccountNotReady, hash, status.FinalityStatus)
+		}
+		txm.lggr.Errorw("account deployment failed, deploying again", "accountAddress", accountAddress, "txhash", hash, "finalityStatus", status.FinalityStatus, "executionStatus", status.ExecutionStatus)
+		txm.deployments.setPending(accountAddress, nil)
+	}
+
+	hash, err := txm.deployAccount(ctx, client, accountAddress, publicKey)
+	if err != nil {
+		txm.deployments.setStatus(accountAddress, err)
+		return err
+	}
+	txm.lggr.Infow("account deployment broadcast", "accountAddress", accountAddress, "txhash", hash)
+	txm.deployments.setPending(accountAddress, hash)
+	txm.deployments.setStatus(accountAddress, fmt.Errorf("%w: deployment %s is pending", ErrAccountNotReady, hash))
+	return fmt.Errorf("%w: deployment %s is pending", ErrAccountNotReady, hash)
+}
+
+func (txm *starktxm) deployAccount(ctx context.Context, client *starknet.Client, accountAddress, publicKey *felt.Felt) (*felt.Felt, error) {
+	strategy, ok := txm.accountStrategy(accountAddress, publicKey).(*openZeppelinAccount)
+	if !ok {
+		return nil, fmt.Errorf("account %s is not deployed, only openzeppelin accounts are deployed automatically", accountAddress)
+	}
+	classHash, err := starknetutils.HexToFelt(txm.cfg.AccountClassHash())
+	if err != nil {
+		return nil, fmt.Errorf("invalid account class hash: %w", err)
+	}
+
+	account, err := starknetaccount.NewAccount(client.Provider, accountAddress, publicKey.String(), txm.ks, 2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new account: %+w", err)
+	}
+
+	constructorCalldata := []*felt.Felt{strategy.publicKey}
+	counterfactual, err := account.PrecomputeAddress(&felt.Zero, strategy.publicKey, classHash, constructorCalldata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute account address: %w", err)
+	}
+	if !counterfactual.Equal(accountAddress) {
+		return nil, fmt.Errorf("account %s is not deployed and doesn't match address %s of class %s for key %s", accountAddress, counterfactual, classHash, strategy.publicKey)
+	}
+
+	tx := starknetrpc.DeployAccountTxnV3{
+		Type:                starknetrpc.TransactionType_DeployAccount,
+		Version:             starknetrpc.TransactionV3,
+		Signature:           []*felt.Felt{},
+		Nonce:               &felt.Zero,
+		ContractAddressSalt: strategy.publicKey,
+		ConstructorCalldata: constructorCalldata,
+		ClassHash:           classHash,
+		ResourceBounds: starknetrpc.ResourceBoundsMapping{
+			L1Gas: starknetrpc.ResourceBounds{MaxAmount: "0x0", MaxPricePerUnit: "0x0"},
+			L2Gas: starknetrpc.ResourceBounds{MaxAmount: "0x0", MaxPricePerUnit: "0x0"},
+		},
+		Tip:           "0x0",
+		PayMasterData: []*felt.Felt{},
+		NonceDataMode: starknetrpc.DAModeL1,
+		FeeMode:       starknetrpc.DAModeL1,
+	}
+
+	simFlags := []starknetrpc.SimulationFlag{starknetrpc.SKIP_VALIDATE}
+	var feeEstimate []starknetrpc.FeeEstimate
+	err = client.Instrument(ctx, "starknet_estimateFee", func(ctx context.Context) (err error) {
+		feeEstimate, err = client.Provider.EstimateFee(ctx, []starknetrpc.BroadcastTxn{tx}, simFlags, starknetrpc.BlockID{Tag: "pending"})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate account deployment fee: %+w", err)
+	}
+	var friEstimate *starknetrpc.FeeEstimate
+	for i := range feeEstimate {
+		if feeEstimate[i].FeeUnit == "FRI" {
+			friEstimate = &feeEstimate[i]
+		}
+	}
+	if friEstimate == nil {
+		return nil, errors.New("no FRI estimate was returned for the account deployment")
+	}
+	tx.ResourceBounds.L1Gas = l1GasBounds(friEstimate)
+
+	hash, err := account.TransactionHashDeployAccount(tx, accountAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash account deployment: %w", err)
+	}
+	signCtx := keystore.WithSignRequest(ctx, keystore.SignRequest{AccountAddress: accountAddress, DeployAccount: &tx})
+	tx.Signature, err = strategy.Sign(signCtx, txm.ks, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign account deployment: %w", err)
+	}
+
+	execCtx, execCancel := context.WithTimeout(ctx, txm.cfg.TxTimeout())
+	defer execCancel()
+	var res *starknetrpc.AddDeployAccountTransactionResponse
+	err = client.Instrument(execCtx, "starknet_addDeployAccountTransaction", func(ctx context.Context) (err error) {
+		res, err = account.AddDeployAccountTransaction(ctx, starknetrpc.BroadcastDeployAccountTxnV3{DeployAccountTxnV3: tx})
+		return err
+	})
+	if err != nil {
+		if isRPCError(err, starknetrpc.ErrInsufficientAccountBalance) {
+			maxFee := new(big.Int).Mul(
+				starknetutils.HexToBN(string(tx.ResourceBounds.L1Gas.MaxAmount)),
+				starknetutils.HexToBN(string(tx.ResourceBounds.L1Gas.MaxPricePerUnit)),
+			)
+			txm.lggr.Warnw("account is waiting for funding, send STRK to the account address to deploy it", "accountAddress", accountAddress, "requiredFri", maxFee)
+			return nil, fmt.Errorf("%w: waiting for funding, requires at least %s FRI at %s", ErrAccountNotReady, maxFee, accountAddress)
+		}
+		return nil, fmt.Errorf("failed to deploy account: %+w", err)
+	}
+	if res == nil {
+		return nil, errors.New("deploy account response and error are nil")
+	}
+	return res.TransactionHash, nil
+}
+
+// l1GasBounds pads the FRI estimate, which skips validation, to 150% of its gas units and gas price
+func l1GasBounds(friEstimate *starknetrpc.FeeEstimate) starknetrpc.ResourceBounds {
+	gasPrice := friEstimate.GasPrice.BigInt(new(big.Int))
+	overallFee := friEstimate.OverallFee.BigInt(new(big.Int)) // overallFee = gas_used*gas_price + data_gas_used*data_gas_price
+
+	gasUnits := new(big.Int).Div(overallFee, gasPrice)
+	maxGasUnits := new(big.Int).Div(new(big.Int).Mul(gasUnits, big.NewInt(150)), big.NewInt(100))
+	maxGasPrice := new(big.Int).Div(new(big.Int).Mul(gasPrice, big.NewInt(150)), big.NewInt(100))
+
+	return starknetrpc.ResourceBounds{
+		MaxAmount:       starknetrpc.U64(starknetutils.BigIntToFelt(maxGasUnits).String()),
+		MaxPricePerUnit: starknetrpc.U128(starknetutils.BigIntToFelt(maxGasPrice).String()),
+	}
+}
+
+func isRPCError(err error, target *starknetrpc.RPCError) bool {
+	var rpcErr *starknetrpc.RPCError
+	return errors.As(err, &rpcErr) && rpcErr.Code == target.Code
+}
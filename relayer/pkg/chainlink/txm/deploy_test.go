@@ -0,0 +1,141 @@
+package txm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetaccount "github.com/NethermindEth/starknet.go/account"
+	"github.com/NethermindEth/starknet.go/curve"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	adapters "github.com/smartcontractkit/chainlink-common/pkg/loop/adapters/starknet"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/txm/mocks"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+)
+
+// singleKeystore is a loop.Keystore holding one private key
+type singleKeystore struct {
+	privateKey *big.Int
+}
+
+func (ks *singleKeystore) Sign(_ context.Context, _ string, hash []byte) ([]byte, error) {
+	if hash == nil {
+		return nil, nil
+	}
+	r, s, err := curve.Curve.Sign(new(big.Int).SetBytes(hash), ks.privateKey)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := adapters.SignatureFromBigInts(r, s)
+	if err != nil {
+		return nil, err
+	}
+	return sig.Bytes()
+}
+
+func (ks *singleKeystore) Accounts(context.Context) ([]string, error) { return nil, nil }
+
+func TestTxm_EnsureDeployed(t *testing.T) {
+	classHash := "0x61dac032f228abef9c6626f995015233097ae253a7f72d68552db02f2971b8f"
+	privateKey := big.NewInt(0x1234)
+	x, _, err := curve.Curve.PrivateToPoint(privateKey)
+	require.NoError(t, err)
+	publicKey := starknetutils.BigIntToFelt(x)
+	classHashFelt, err := starknetutils.HexToFelt(classHash)
+	require.NoError(t, err)
+	accountAddress, err := (&starknetaccount.Account{}).PrecomputeAddress(&felt.Zero, publicKey, classHashFelt, []*felt.Felt{publicKey})
+	require.NoError(t, err)
+
+	deployed := false
+	funded := false
+	var deploys int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, _ := io.ReadAll(r.Body)
+		var call struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+		}
+		require.NoError(t, json.Unmarshal(req, &call))
+
+		out := `{"result": null}`
+		switch call.Method {
+		case "starknet_chainId":
+			out = `{"result": "0x534e5f5345504f4c4941"}`
+		case "starknet_getClassHashAt":
+			if deployed {
+				out = `{"result": "` + classHash + `"}`
+			} else {
+				out = `{"error": {"code": 20, "message": "Contract not found"}}`
+			}
+		case "starknet_estimateFee":
+			out = `{"result": [{"gas_consumed": "0x10", "gas_price": "0x2", "data_gas_consumed": "0x0", "data_gas_price": "0x1", "overall_fee": "0x20", "unit": "FRI"}]}`
+		case "starknet_addDeployAccountTransaction":
+			deploys++
+			if funded {
+				out = `{"result": {"transaction_hash": "0xabc", "contract_address": "` + accountAddress.String() + `"}}`
+			} else {
+				out = `{"error": {"code": 54, "message": "Account balance is smaller than the transaction's max_fee"}}`
+			}
+		case "starknet_getTransactionStatus":
+			out = `{"result": {"finality_status": "RECEIVED"}}`
+		default:
+			require.Fail(t, "unexpected method "+call.Method)
+		}
+		_, err := fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": %d, %s`, call.ID, out[1:])
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	lggr := logger.Test(t)
+	timeout := 5 * time.Second
+	client, err := starknet.NewClient("SN_SEPOLIA", server.URL, "", lggr, &timeout)
+	require.NoError(t, err)
+
+	cfg := mocks.NewConfig(t)
+	cfg.On("AccountClassHash").Return(classHash)
+	cfg.On("TxTimeout").Return(timeout).Maybe()
+	txm, err := New(lggr, &singleKeystore{privateKey: privateKey}, cfg, func() (*starknet.Client, error) { return client, nil }, nil)
+	require.NoError(t, err)
+	impl := txm.(*starktxm)
+	ctx := tests.Context(t)
+
+	// the address doesn't belong to the key
+	err = impl.ensureDeployed(ctx, client, new(felt.Felt).SetUint64(1), publicKey)
+	require.ErrorContains(t, err, "doesn't match address "+accountAddress.String())
+	assert.Equal(t, 0, deploys)
+
+	// 0x20 FRI at a gas price of 2, padded to 24 gas units at a gas price of 3
+	err = impl.ensureDeployed(ctx, client, accountAddress, publicKey)
+	require.ErrorIs(t, err, ErrAccountNotReady)
+	require.ErrorContains(t, err, "waiting for funding, requires at least 72 FRI")
+	assert.ErrorContains(t, txm.HealthReport()[txm.Name()+".Account."+accountAddress.String()], "waiting for funding")
+
+	funded = true
+	err = impl.ensureDeployed(ctx, client, accountAddress, publicKey)
+	require.ErrorIs(t, err, ErrAccountNotReady)
+	require.ErrorContains(t, err, "deployment 0xabc is pending")
+	assert.Equal(t, 2, deploys)
+
+	// not resent while pending
+	err = impl.ensureDeployed(ctx, client, accountAddress, publicKey)
+	require.ErrorContains(t, err, "deployment 0xabc is RECEIVED")
+	assert.Equal(t, 2, deploys)
+
+	deployed = true
+	require.NoError(t, impl.ensureDeployed(ctx, client, accountAddress, publicKey))
+	assert.NotContains(t, txm.HealthReport(), txm.Name()+".Account."+accountAddress.String())
+	assert.Nil(t, impl.deployments.getPending(accountAddress))
+}
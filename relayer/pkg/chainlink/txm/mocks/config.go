@@ -13,6 +13,24 @@ type Config struct {
 	mock.Mock
 }
 
+// AccountClassHash provides a mock function with given fields:
+func (_m *Config) AccountClassHash() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AccountClassHash")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // ConfirmationPoll provides a mock function with given fields:
 func (_m *Config) ConfirmationPoll() time.Duration {
 	ret := _m.Called()
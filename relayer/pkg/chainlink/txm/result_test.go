@@ -0,0 +1,140 @@
+package txm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/txm/mocks"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+)
+
+func TestTxm_EnqueueWithResult(t *testing.T) {
+	// every invoke gets the next hash, 0x2 reverts
+	var lock sync.Mutex
+	var invokes uint64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, _ := io.ReadAll(r.Body)
+		var call struct {
+			ID     int               `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.Unmarshal(req, &call))
+
+		lock.Lock()
+		defer lock.Unlock()
+		var out string
+		switch call.Method {
+		case "starknet_chainId":
+			out = `"0x534e5f5345504f4c4941"`
+		case "starknet_getNonce":
+			out = fmt.Sprintf(`"0x%x"`, invokes)
+		case "starknet_estimateFee":
+			out = `[{"gas_consumed": "0x10", "gas_price": "0x2", "data_gas_consumed": "0x0", "data_gas_price": "0x1", "overall_fee": "0x20", "unit": "FRI"}]`
+		case "starknet_addInvokeTransaction":
+			invokes++
+			out = fmt.Sprintf(`{"transaction_hash": "0x%x"}`, invokes)
+		case "starknet_getTransactionStatus":
+			execution := "SUCCEEDED"
+			if string(call.Params[0]) == `"0x2"` {
+				execution = "REVERTED"
+			}
+			out = `{"finality_status": "ACCEPTED_ON_L2", "execution_status": "` + execution + `"}`
+		default:
+			require.Fail(t, "unexpected method "+call.Method)
+		}
+		_, err := fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": %d, "result": %s}`, call.ID, out)
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	lggr := logger.Test(t)
+	timeout := 5 * time.Second
+	client, err := starknet.NewClient("SN_SEPOLIA", server.URL, "", lggr, &timeout)
+	require.NoError(t, err)
+
+	cfg := mocks.NewConfig(t)
+	cfg.On("AccountClassHash").Return("").Maybe()
+	cfg.On("TxTimeout").Return(timeout).Maybe()
+	cfg.On("ConfirmationPoll").Return(10 * time.Millisecond)
+	txm, err := New(lggr, &singleKeystore{privateKey: big.NewInt(0x1234)}, cfg, func() (*starknet.Client, error) { return client, nil }, nil)
+	require.NoError(t, err)
+	ctx := tests.Context(t)
+	require.NoError(t, txm.Start(ctx))
+	defer txm.Close()
+
+	accountAddress, publicKey := new(felt.Felt).SetUint64(0xacc), new(felt.Felt).SetUint64(1)
+	call := starknetrpc.FunctionCall{ContractAddress: new(felt.Felt).SetUint64(0xc), EntryPointSelector: new(felt.Felt).SetUint64(0xd)}
+	succeeded, err := txm.EnqueueWithResult(ctx, accountAddress, publicKey, call)
+	require.NoError(t, err)
+	reverted, err := txm.EnqueueWithResult(ctx, accountAddress, publicKey, call)
+	require.NoError(t, err)
+
+	for _, expected := range []TxResult{
+		{Hash: "0x1"},
+		{Hash: "0x2", Err: fmt.Errorf("transaction 0x2 reverted")},
+	} {
+		result := succeeded
+		if expected.Err != nil {
+			result = reverted
+		}
+		select {
+		case res := <-result:
+			assert.Equal(t, expected, res)
+		case <-ctx.Done():
+			t.Fatal("no result for", expected.Hash)
+		}
+	}
+}
+
+func TestTxm_ResultsWithoutBroadcast(t *testing.T) {
+	lggr := logger.Test(t)
+	cfg := mocks.NewConfig(t)
+	cfg.On("ConfirmationPoll").Return(time.Hour).Maybe()
+	txm, err := New(lggr, &singleKeystore{privateKey: big.NewInt(0x1234)}, cfg, func() (*starknet.Client, error) {
+		return nil, errors.New("no nodes")
+	}, nil)
+	require.NoError(t, err)
+	ctx := tests.Context(t)
+	impl := txm.(*starktxm)
+
+	accountAddress, publicKey := new(felt.Felt).SetUint64(0xacc), new(felt.Felt).SetUint64(1)
+	call := starknetrpc.FunctionCall{ContractAddress: new(felt.Felt).SetUint64(0xc), EntryPointSelector: new(felt.Felt).SetUint64(0xd)}
+	require.NoError(t, txm.Start(ctx))
+
+	t.Run("no client", func(t *testing.T) {
+		result, err := txm.EnqueueWithResult(ctx, accountAddress, publicKey, call)
+		require.NoError(t, err)
+		select {
+		case res := <-result:
+			assert.ErrorContains(t, res.Err, "no nodes")
+		case <-ctx.Done():
+			t.Fatal("no result")
+		}
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		unconfirmed := make(chan TxResult, 1)
+		impl.watchResult("0x1", unconfirmed)
+		require.NoError(t, txm.Close())
+		res := <-unconfirmed
+		assert.Equal(t, "0x1", res.Hash)
+		assert.ErrorContains(t, res.Err, "txm closed")
+	})
+}
@@ -0,0 +1,197 @@
+package txm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+)
+
+var (
+	// ErrKeyRotation is returned when enqueueing a transaction for an account whose key is being rotated
+	ErrKeyRotation = errors.New("account key rotation in progress")
+	// ErrKeyMismatch fails the transactions of a registered account controlled on-chain by a key the keystore lacks
+	ErrKeyMismatch = errors.New("account key mismatch")
+)
+
+// KeyRotator rotates the signing key of the OpenZeppelin accounts driven by the TXM
+type KeyRotator interface {
+	// RotateKey hands an account over from currentKey to newKey, both need to be in the keystore. New transactions of
+	// the account are rejected with ErrKeyRotation until it returns. The account is drained of queued and unconfirmed
+	// transactions, set_public_key is sent signed with currentKey and once the account reports newKey on-chain, the
+	// TXM signs all further transactions of the account with newKey.
+	// On error the account keeps signing with currentKey, unless the on-chain key was changed, which is reported.
+	RotateKey(ctx context.Context, accountAddress, currentKey, newKey *felt.Felt) error
+}
+
+var _ KeyRotator = (*starktxm)(nil)
+
+func (txm *starktxm) RotateKey(ctx context.Context, accountAddress, currentKey, newKey *felt.Felt) error {
+	if _, ok := txm.accountStrategy(accountAddress, currentKey).(*openZeppelinAccount); !ok {
+		return fmt.Errorf("key rotation is only supported for openzeppelin accounts")
+	}
+	for _, key := range []*felt.Felt{currentKey, newKey} {
+		if _, err := txm.ks.Loopp().Sign(ctx, key.String(), nil); err != nil {
+			return fmt.Errorf("key %s is not in the keystore: %w", key, err)
+		}
+	}
+	client, err := txm.client.Get()
+	if err != nil {
+		return fmt.Errorf("failed to fetch client: %w", err)
+	}
+
+	if err = txm.startRotation(accountAddress); err != nil {
+		return err
+	}
+	defer txm.endRotation(accountAddress)
+
+	if err = txm.drain(ctx, accountAddress); err != nil {
+		return fmt.Errorf("couldn't drain account before rotation: %w", err)
+	}
+	onchainKey, err := accountPublicKey(ctx, client, accountAddress)
+	if err != nil {
+		return err
+	}
+	if !onchainKey.Equal(currentKey) {
+		return fmt.Errorf("account %s is controlled by key %s, not %s", accountAddress, onchainKey, currentKey)
+	}
+
+	// signed with the current key, the account has to be pinned to it in case it was not registered
+	txm.setAccountKey(accountAddress, currentKey)
+	call := starknetrpc.FunctionCall{
+		ContractAddress:    accountAddress,
+		EntryPointSelector: starknetutils.GetSelectorFromNameFelt("set_public_key"),
+		Calldata:           []*felt.Felt{newKey},
+	}
+	if err = txm.enqueueRotation(ctx, accountAddress, currentKey, call); err != nil {
+		return fmt.Errorf("couldn't enqueue set_public_key: %w", err)
+	}
+	if err = txm.drain(ctx, accountAddress); err != nil {
+		return fmt.Errorf("couldn't confirm set_public_key: %w", err)
+	}
+
+	onchainKey, err = accountPublicKey(ctx, client, accountAddress)
+	if err != nil {
+		return fmt.Errorf("couldn't verify rotation, account may be controlled by %s: %w", newKey, err)
+	}
+	if !onchainKey.Equal(newKey) {
+		return fmt.Errorf("set_public_key did not take effect, account is controlled by key %s", onchainKey)
+	}
+
+	txm.setAccountKey(accountAddress, newKey)
+	txm.lggr.Infow("rotated account key", "accountAddress", accountAddress, "previousKey", currentKey, "newKey", newKey)
+	return nil
+}
+
+// checkAccountKey makes sure a registered OpenZeppelin account signs with the key controlling it on-chain, before
+// its first transaction. The chain is what keeps a rotation across restarts: an on-chain key that is in the keystore
+// is adopted over the registered one, which is stale until the relay config is updated. Otherwise the transactions
+// of the account fail with ErrKeyMismatch, which is reported in the health report until the account is registered
+// again. Other accounts are not checked.
+func (txm *starktxm) checkAccountKey(ctx context.Context, client *starknet.Client, accountAddress *felt.Felt) error {
+	txm.accountsLock.RLock()
+	account := txm.accounts[accountAddress.String()]
+	_, checked := txm.checkedKeys[accountAddress.String()]
+	txm.accountsLock.RUnlock()
+	oz, ok := account.(*openZeppelinAccount)
+	if !ok || checked {
+		return nil
+	}
+
+	onchainKey, err := accountPublicKey(ctx, client, accountAddress)
+	if err != nil {
+		// checked again with the next transaction, which fails on its own if the key is wrong
+		txm.lggr.Warnw("couldn't check the key of the account", "accountAddress", accountAddress, "err", err)
+		return nil
+	}
+	if !onchainKey.Equal(oz.publicKey) {
+		if _, err = txm.ks.Loopp().Sign(ctx, onchainKey.String(), nil); err != nil {
+			err = fmt.Errorf("%w: account %s is controlled by key %s, not %s", ErrKeyMismatch, accountAddress, onchainKey, oz.publicKey)
+			txm.accountsLock.Lock()
+			txm.keyErrs[accountAddress.String()] = err
+			txm.accountsLock.Unlock()
+			return err
+		}
+		txm.lggr.Warnw("account key was rotated, signing with the on-chain key over the registered one",
+			"accountAddress", accountAddress, "registeredKey", oz.publicKey, "onchainKey", onchainKey)
+	}
+	txm.setAccountKey(accountAddress, onchainKey)
+	return nil
+}
+
+// setAccountKey signs the transactions of the account with a key known to control it on-chain
+func (txm *starktxm) setAccountKey(accountAddress, key *felt.Felt) {
+	txm.accountsLock.Lock()
+	defer txm.accountsLock.Unlock()
+	txm.accounts[accountAddress.String()] = NewOpenZeppelinAccount(key)
+	txm.checkedKeys[accountAddress.String()] = struct{}{}
+	delete(txm.keyErrs, accountAddress.String())
+}
+
+// enqueueRotation queues a call of an account that is being rotated, which enqueue rejects
+func (txm *starktxm) enqueueRotation(ctx context.Context, accountAddress, publicKey *felt.Felt, call starknetrpc.FunctionCall) error {
+	txm.queuedLock.Lock()
+	defer txm.queuedLock.Unlock()
+	return txm.push(Tx{publicKey: publicKey, accountAddress: accountAddress, call: call, spanContext: trace.SpanContextFromContext(ctx)})
+}
+
+// startRotation holds back new transactions of the account, it takes queuedLock so that no transaction is queued
+// after it returns
+func (txm *starktxm) startRotation(accountAddress *felt.Felt) error {
+	txm.queuedLock.Lock()
+	defer txm.queuedLock.Unlock()
+	if _, ok := txm.rotating[accountAddress.String()]; ok {
+		return fmt.Errorf("%w for account %s", ErrKeyRotation, accountAddress)
+	}
+	txm.rotating[accountAddress.String()] = struct{}{}
+	return nil
+}
+
+func (txm *starktxm) endRotation(accountAddress *felt.Felt) {
+	txm.queuedLock.Lock()
+	defer txm.queuedLock.Unlock()
+	delete(txm.rotating, accountAddress.String())
+}
+
+func (txm *starktxm) isRotating(accountAddress *felt.Felt) bool {
+	txm.queuedLock.Lock()
+	defer txm.queuedLock.Unlock()
+	_, ok := txm.rotating[accountAddress.String()]
+	return ok
+}
+
+// drain waits until the account has no queued or unconfirmed transactions
+func (txm *starktxm) drain(ctx context.Context, accountAddress *felt.Felt) error {
+	for {
+		queued, unconfirmed := txm.AccountInflightCount(accountAddress)
+		if queued == 0 && unconfirmed == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d queued and %d unconfirmed txs left: %w", queued, unconfirmed, ctx.Err())
+		case <-time.After(txm.cfg.ConfirmationPoll()):
+		}
+	}
+}
+
+func accountPublicKey(ctx context.Context, client *starknet.Client, accountAddress *felt.Felt) (*felt.Felt, error) {
+	res, err := client.CallContract(ctx, starknet.CallOps{
+		ContractAddress: accountAddress,
+		Selector:        starknetutils.GetSelectorFromNameFelt("get_public_key"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch public key of account %s: %w", accountAddress, err)
+	}
+	if len(res) != 1 {
+		return nil, fmt.Errorf("unexpected get_public_key response length %d", len(res))
+	}
+	return res[0], nil
+}
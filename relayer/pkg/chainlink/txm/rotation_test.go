@@ -0,0 +1,138 @@
+package txm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/txm/mocks"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+)
+
+func TestTxm_RotateKey(t *testing.T) {
+	accountAddress := new(felt.Felt).SetUint64(0xacc)
+	currentKey, newKey := new(felt.Felt).SetUint64(1), new(felt.Felt).SetUint64(2)
+
+	// the account switches to the key of the last set_public_key it received
+	var lock sync.Mutex
+	onchainKey := currentKey
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, _ := io.ReadAll(r.Body)
+		var call struct {
+			ID     int               `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.Unmarshal(req, &call))
+
+		lock.Lock()
+		defer lock.Unlock()
+		var out string
+		switch call.Method {
+		case "starknet_chainId":
+			out = `"0x534e5f5345504f4c4941"`
+		case "starknet_getNonce":
+			out = `"0x0"`
+		case "starknet_call":
+			out = `["` + onchainKey.String() + `"]`
+		case "starknet_estimateFee":
+			out = `[{"gas_consumed": "0x10", "gas_price": "0x2", "data_gas_consumed": "0x0", "data_gas_price": "0x1", "overall_fee": "0x20", "unit": "FRI"}]`
+		case "starknet_addInvokeTransaction":
+			var tx starknetrpc.InvokeTxnV3
+			require.NoError(t, json.Unmarshal(call.Params[0], &tx))
+			// [len(calls), to, selector, len(calldata), new key]
+			require.Len(t, tx.Calldata, 5)
+			assert.Equal(t, starknetutils.GetSelectorFromNameFelt("set_public_key"), tx.Calldata[2])
+			onchainKey = tx.Calldata[4]
+			out = `{"transaction_hash": "0xabc"}`
+		case "starknet_getTransactionStatus":
+			out = `{"finality_status": "ACCEPTED_ON_L2", "execution_status": "SUCCEEDED"}`
+		default:
+			require.Fail(t, "unexpected method "+call.Method)
+		}
+		_, err := fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": %d, "result": %s}`, call.ID, out)
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	lggr := logger.Test(t)
+	timeout := 5 * time.Second
+	client, err := starknet.NewClient("SN_SEPOLIA", server.URL, "", lggr, &timeout)
+	require.NoError(t, err)
+
+	cfg := mocks.NewConfig(t)
+	cfg.On("AccountClassHash").Return("").Maybe()
+	cfg.On("TxTimeout").Return(timeout).Maybe()
+	cfg.On("ConfirmationPoll").Return(10 * time.Millisecond)
+	ks := &knownKeys{singleKeystore: singleKeystore{privateKey: big.NewInt(0x1234)}, keys: []*felt.Felt{currentKey, newKey}}
+	txm, err := New(lggr, ks, cfg, func() (*starknet.Client, error) { return client, nil }, nil)
+	require.NoError(t, err)
+	ctx := tests.Context(t)
+	require.NoError(t, txm.Start(ctx))
+	defer txm.Close()
+	impl := txm.(*starktxm)
+
+	// transactions are held back while rotating
+	require.NoError(t, impl.startRotation(accountAddress))
+	require.ErrorIs(t, txm.Enqueue(ctx, accountAddress, currentKey, starknetrpc.FunctionCall{ContractAddress: accountAddress}), ErrKeyRotation)
+	require.ErrorIs(t, txm.RotateKey(ctx, accountAddress, currentKey, newKey), ErrKeyRotation)
+	impl.endRotation(accountAddress)
+
+	// the account is not controlled by the key
+	require.ErrorContains(t, txm.RotateKey(ctx, accountAddress, newKey, currentKey), "is controlled by key 0x1, not 0x2")
+
+	require.NoError(t, txm.RotateKey(ctx, accountAddress, currentKey, newKey))
+	lock.Lock()
+	assert.Equal(t, newKey, onchainKey)
+	lock.Unlock()
+	assert.Equal(t, NewOpenZeppelinAccount(newKey), impl.accountStrategy(accountAddress, currentKey))
+	assert.False(t, impl.isRotating(accountAddress))
+
+	// providers created from a stale relay config, e.g. after a restart, sign with the rotated key once it is checked
+	txm.RegisterAccount(accountAddress, NewOpenZeppelinAccount(currentKey))
+	require.NoError(t, impl.checkAccountKey(ctx, client, accountAddress))
+	assert.Equal(t, NewOpenZeppelinAccount(newKey), impl.accountStrategy(accountAddress, currentKey))
+
+	// accounts controlled by a key the keystore lacks fail their transactions and are reported
+	otherAccount := new(felt.Felt).SetUint64(0xbbb)
+	lock.Lock()
+	onchainKey = new(felt.Felt).SetUint64(3)
+	lock.Unlock()
+	txm.RegisterAccount(otherAccount, NewOpenZeppelinAccount(currentKey))
+	require.ErrorIs(t, impl.checkAccountKey(ctx, client, otherAccount), ErrKeyMismatch)
+	assert.ErrorIs(t, txm.HealthReport()[txm.Name()+".AccountKey."+otherAccount.String()], ErrKeyMismatch)
+	assert.Equal(t, NewOpenZeppelinAccount(currentKey), impl.accountStrategy(otherAccount, currentKey))
+	txm.RegisterAccount(otherAccount, NewOpenZeppelinAccount(newKey))
+	assert.NotContains(t, txm.HealthReport(), txm.Name()+".AccountKey."+otherAccount.String())
+}
+
+// knownKeys is a singleKeystore that only holds the listed keys
+type knownKeys struct {
+	singleKeystore
+	keys []*felt.Felt
+}
+
+func (ks *knownKeys) Sign(ctx context.Context, id string, hash []byte) ([]byte, error) {
+	for _, key := range ks.keys {
+		if key.String() == id {
+			return ks.singleKeystore.Sign(ctx, id, hash)
+		}
+	}
+	return nil, fmt.Errorf("no key %s", id)
+}
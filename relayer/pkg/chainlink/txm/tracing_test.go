@@ -0,0 +1,72 @@
+package txm
+
+import (
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/txm/mocks"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+)
+
+func TestTxm_BroadcastTracing(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+
+	// the node is down, the broadcast fails on its first request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	lggr := logger.Test(t)
+	timeout := 5 * time.Second
+	client, err := starknet.NewClient("SN_SEPOLIA", server.URL, "", lggr, &timeout)
+	require.NoError(t, err)
+
+	cfg := mocks.NewConfig(t)
+	cfg.On("AccountClassHash").Return("")
+	cfg.On("ConfirmationPoll").Return(time.Hour).Maybe()
+	txm, err := New(lggr, &singleKeystore{privateKey: big.NewInt(0x1234)}, cfg, func() (*starknet.Client, error) { return client, nil }, nil)
+	require.NoError(t, err)
+	ctx := tests.Context(t)
+	require.NoError(t, txm.Start(ctx))
+	t.Cleanup(func() { require.NoError(t, txm.Close()) })
+
+	ctx, transmit := otel.Tracer("test").Start(ctx, "transmit")
+	address := new(felt.Felt).SetUint64(1)
+	require.NoError(t, txm.Enqueue(ctx, address, address, starknetrpc.FunctionCall{ContractAddress: address}))
+	transmit.End()
+
+	var broadcast, nonce sdktrace.ReadOnlySpan
+	require.Eventually(t, func() bool {
+		for _, span := range spans.Ended() {
+			switch span.Name() {
+			case "txm.broadcast":
+				broadcast = span
+			case "starknet_getNonce":
+				nonce = span
+			}
+		}
+		return broadcast != nil && nonce != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// enqueue -> broadcast -> node request
+	assert.Equal(t, transmit.SpanContext().TraceID(), broadcast.SpanContext().TraceID())
+	assert.Equal(t, transmit.SpanContext().SpanID(), broadcast.Parent().SpanID())
+	assert.Equal(t, broadcast.SpanContext().SpanID(), nonce.Parent().SpanID())
+	assert.NotEmpty(t, broadcast.Events(), "the failure is recorded")
+}
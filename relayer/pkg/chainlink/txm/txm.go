@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/big"
 	"strings"
 	"sync"
 	"time"
@@ -13,12 +12,17 @@ import (
 	starknetaccount "github.com/NethermindEth/starknet.go/account"
 	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
 	starknetutils "github.com/NethermindEth/starknet.go/utils"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/loop"
 	"github.com/smartcontractkit/chainlink-common/pkg/services"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils"
 
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/keystore"
 	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
 )
 
@@ -26,20 +30,48 @@ const (
 	MaxQueueLen = 1000
 )
 
+var tracer = otel.Tracer("github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/txm")
+
 type TxManager interface {
 	Enqueue(ctx context.Context, accountAddress *felt.Felt, publicKey *felt.Felt, txFn starknetrpc.FunctionCall) error
 	InflightCount() (int, int)
+	// AccountInflightCount returns the number of queued and unconfirmed txs of a single account
+	AccountInflightCount(accountAddress *felt.Felt) (int, int)
+	// PendingCalls returns the calls to a contract that are queued or broadcast but not yet confirmed
+	PendingCalls(contractAddress *felt.Felt) []starknetrpc.FunctionCall
+	// RegisterAccount sets how transactions of an account are built and signed, accounts that are not registered
+	// are OpenZeppelin accounts signing with the public key they are enqueued with. The key of a registered
+	// OpenZeppelin account is checked on-chain before its first transaction.
+	RegisterAccount(accountAddress *felt.Felt, account AccountStrategy)
 }
 
 type Tx struct {
 	publicKey      *felt.Felt
 	accountAddress *felt.Felt
 	call           starknetrpc.FunctionCall
+	// spanContext of the enqueuing caller, the broadcast is traced as its child
+	spanContext trace.SpanContext
+	// result receives the outcome of the transaction, if set
+	result chan TxResult
+}
+
+// TxResult is the final outcome of a transaction enqueued with EnqueueWithResult
+type TxResult struct {
+	// Hash is set once the transaction was broadcast
+	Hash string
+	// Err is why the transaction failed to broadcast, was rejected or reverted
+	Err error
 }
 
 type StarkTXM interface {
 	services.Service
 	TxManager
+	KeyRotator
+	// EnqueueWithResult enqueues a transaction like Enqueue, the returned channel receives its result once the
+	// transaction failed to broadcast or was confirmed
+	EnqueueWithResult(ctx context.Context, accountAddress, publicKey *felt.Felt, call starknetrpc.FunctionCall) (<-chan TxResult, error)
+	// ResetClients drops the cached RPC and feeder clients, the next transactions load them from the current nodes
+	ResetClients()
 }
 
 type starktxm struct {
@@ -48,12 +80,30 @@ type starktxm struct {
 	done    sync.WaitGroup
 	stop    chan struct{}
 	queue   chan Tx
-	ks      KeystoreAdapter
-	cfg     Config
+	// queued mirrors the calls in queue until they are broadcast, the channel itself can't be inspected
+	queuedLock sync.Mutex
+	queued     []Tx
+	// accounts being rotated, guarded by queuedLock so that no call is queued once a rotation started
+	rotating map[string]struct{}
+
+	ks  KeystoreAdapter
+	cfg Config
 
 	client       *utils.LazyLoad[*starknet.Client]
 	feederClient *utils.LazyLoad[*starknet.FeederClient]
 	accountStore *AccountStore
+
+	// results of the broadcast transactions enqueued with EnqueueWithResult, by hash
+	resultsLock sync.Mutex
+	results     map[string]chan TxResult
+
+	accountsLock sync.RWMutex
+	accounts     map[string]AccountStrategy
+	// OpenZeppelin accounts whose key was checked on-chain since they were registered, and the accounts failing the
+	// check, see checkAccountKey
+	checkedKeys map[string]struct{}
+	keyErrs     map[string]error
+	deployments *accountDeployments
 }
 
 func New(lggr logger.Logger, keystore loop.Keystore, cfg Config, getClient func() (*starknet.Client, error),
@@ -67,11 +117,22 @@ func New(lggr logger.Logger, keystore loop.Keystore, cfg Config, getClient func(
 		ks:           NewKeystoreAdapter(keystore),
 		cfg:          cfg,
 		accountStore: NewAccountStore(),
+		results:      map[string]chan TxResult{},
+		accounts:     map[string]AccountStrategy{},
+		rotating:     map[string]struct{}{},
+		checkedKeys:  map[string]struct{}{},
+		keyErrs:      map[string]error{},
+		deployments:  newAccountDeployments(),
 	}
 
 	return txm, nil
 }
 
+func (txm *starktxm) ResetClients() {
+	txm.client.Reset()
+	txm.feederClient.Reset()
+}
+
 func (txm *starktxm) Name() string {
 	return txm.lggr.Name()
 }
@@ -101,16 +162,33 @@ func (txm *starktxm) broadcastLoop() {
 		case tx := <-txm.queue:
 			if _, err := txm.client.Get(); err != nil {
 				txm.lggr.Errorw("failed to fetch client: skipping processing tx", "error", err)
+				txm.dequeued()
+				if tx.result != nil {
+					tx.result <- TxResult{Err: fmt.Errorf("failed to fetch client: %w", err)}
+				}
 				continue
 			}
 
 			// broadcast tx serially - wait until accepted by mempool before processing next
-			hash, err := txm.broadcast(ctx, tx.publicKey, tx.accountAddress, tx.call)
+			txCtx, span := tracer.Start(trace.ContextWithSpanContext(ctx, tx.spanContext), "txm.broadcast", trace.WithAttributes(
+				attribute.String("starknet.account_address", tx.accountAddress.String()),
+				attribute.String("starknet.contract_address", tx.call.ContractAddress.String()),
+			))
+			hash, err := txm.broadcast(txCtx, tx.publicKey, tx.accountAddress, tx.call, tx.result)
+			// only removed once broadcast so the call is always either queued or unconfirmed
+			txm.dequeued()
 			if err != nil {
 				txm.lggr.Errorw("transaction failed to broadcast", "error", err, "tx", tx.call)
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				if tx.result != nil {
+					tx.result <- TxResult{Hash: hash, Err: err}
+				}
 			} else {
 				txm.lggr.Infow("transaction broadcast", "txhash", hash)
+				span.SetAttributes(attribute.String("starknet.tx_hash", hash))
 			}
+			span.End()
 		}
 	}
 }
@@ -137,7 +215,11 @@ func (txm *starktxm) estimateFriFee(ctx context.Context, client *starknet.Client
 			largestEstimateNonce = estimateNonce
 		}
 
-		feeEstimate, err := client.Provider.EstimateFee(ctx, []starknetrpc.BroadcastTxn{tx}, simFlags, starknetrpc.BlockID{Tag: "pending"})
+		var feeEstimate []starknetrpc.FeeEstimate
+		err = client.Instrument(ctx, "starknet_estimateFee", func(ctx context.Context) (err error) {
+			feeEstimate, err = client.Provider.EstimateFee(ctx, []starknetrpc.BroadcastTxn{tx}, simFlags, starknetrpc.BlockID{Tag: "pending"})
+			return err
+		})
 		if err != nil {
 			var dataErr *starknetrpc.RPCError
 			if !errors.As(err, &dataErr) {
@@ -174,7 +256,8 @@ func (txm *starktxm) estimateFriFee(ctx context.Context, client *starknet.Client
 	return nil, nil, fmt.Errorf("all attempts to estimate fee failed")
 }
 
-func (txm *starktxm) broadcast(ctx context.Context, publicKey *felt.Felt, accountAddress *felt.Felt, call starknetrpc.FunctionCall) (txhash string, err error) {
+// broadcast sends the call, result is sent the outcome once the transaction is confirmed
+func (txm *starktxm) broadcast(ctx context.Context, publicKey *felt.Felt, accountAddress *felt.Felt, call starknetrpc.FunctionCall, result chan TxResult) (txhash string, err error) {
 	client, err := txm.client.Get()
 	if err != nil {
 		txm.client.Reset()
@@ -183,6 +266,9 @@ func (txm *starktxm) broadcast(ctx context.Context, publicKey *felt.Felt, accoun
 
 	txStore := txm.accountStore.GetTxStore(accountAddress)
 	if txStore == nil {
+		if deployErr := txm.ensureDeployed(ctx, client, accountAddress, publicKey); deployErr != nil {
+			return txhash, deployErr
+		}
 		initialNonce, accountNonceErr := client.AccountNonce(ctx, accountAddress)
 		if accountNonceErr != nil {
 			return txhash, fmt.Errorf("failed to check account nonce during TxStore creation: %+w", accountNonceErr)
@@ -193,8 +279,12 @@ func (txm *starktxm) broadcast(ctx context.Context, publicKey *felt.Felt, accoun
 		}
 		txStore = newTxStore
 	}
+	if err = txm.checkAccountKey(ctx, client, accountAddress); err != nil {
+		return txhash, err
+	}
 
-	// create new account
+	// create new account, only used to build, hash and submit the transaction; the signature depends on the strategy
+	strategy := txm.accountStrategy(accountAddress, publicKey)
 	cairoVersion := 2
 	account, err := starknetaccount.NewAccount(client.Provider, accountAddress, publicKey.String(), txm.ks, cairoVersion)
 	if err != nil {
@@ -245,31 +335,12 @@ func (txm *starktxm) broadcast(ctx context.Context, publicKey *felt.Felt, accoun
 		if len(staleTxs) > 0 {
 			txm.lggr.Errorw("unexpected stale transactions after nonce fast-forward", "accountAddress", accountAddress)
 		}
+		txm.dropStale(staleTxs)
 		nonce = largestEstimateNonce
 	}
 
 	// TODO: consider making this configurable
-	// pad estimate to 250% (add extra because estimate did not include validation)
-	gasConsumed := friEstimate.GasConsumed.BigInt(new(big.Int))
-	expandedGas := new(big.Int).Mul(gasConsumed, big.NewInt(250))
-	maxGas := new(big.Int).Div(expandedGas, big.NewInt(100))
-	tx.ResourceBounds.L1Gas.MaxAmount = starknetrpc.U64(starknetutils.BigIntToFelt(maxGas).String())
-
-	// pad by 150%
-	gasPrice := friEstimate.GasPrice.BigInt(new(big.Int))
-	overallFee := friEstimate.OverallFee.BigInt(new(big.Int)) // overallFee = gas_used*gas_price + data_gas_used*data_gas_price
-
-	// TODO: consider making this configurable
-	// pad estimate to 150% (add extra because estimate did not include validation)
-	gasUnits := new(big.Int).Div(overallFee, gasPrice)
-	expandedGasUnits := new(big.Int).Mul(gasUnits, big.NewInt(150))
-	maxGasUnits := new(big.Int).Div(expandedGasUnits, big.NewInt(100))
-	tx.ResourceBounds.L1Gas.MaxAmount = starknetrpc.U64(starknetutils.BigIntToFelt(maxGasUnits).String())
-
-	// pad by 150%
-	expandedGasPrice := new(big.Int).Mul(gasPrice, big.NewInt(150))
-	maxGasPrice := new(big.Int).Div(expandedGasPrice, big.NewInt(100))
-	tx.ResourceBounds.L1Gas.MaxPricePerUnit = starknetrpc.U128(starknetutils.BigIntToFelt(maxGasPrice).String())
+	tx.ResourceBounds.L1Gas = l1GasBounds(friEstimate)
 
 	txm.lggr.Infow("Set resource bounds", "L1MaxAmount", tx.ResourceBounds.L1Gas.MaxAmount, "L1MaxPricePerUnit", tx.ResourceBounds.L1Gas.MaxPricePerUnit)
 
@@ -280,7 +351,13 @@ func (txm *starktxm) broadcast(ctx context.Context, publicKey *felt.Felt, accoun
 	if err != nil {
 		return txhash, err
 	}
-	signature, err := account.Sign(ctx, hash)
+	// attach the transaction for keystores that check what they sign
+	signCtx := keystore.WithSignRequest(ctx, keystore.SignRequest{
+		AccountAddress: accountAddress,
+		Calls:          []starknetrpc.FunctionCall{call},
+		Invoke:         &tx,
+	})
+	signature, err := strategy.Sign(signCtx, txm.ks, hash)
 	if err != nil {
 		return txhash, err
 	}
@@ -290,7 +367,11 @@ func (txm *starktxm) broadcast(ctx context.Context, publicKey *felt.Felt, accoun
 	defer execCancel()
 
 	// finally, transmit the invoke
-	res, err := account.AddInvokeTransaction(execCtx, tx)
+	var res *starknetrpc.AddInvokeTransactionResponse
+	err = client.Instrument(execCtx, "starknet_addInvokeTransaction", func(ctx context.Context) (err error) {
+		res, err = account.AddInvokeTransaction(ctx, tx)
+		return err
+	})
 	if err != nil {
 		// TODO: handle initial broadcast errors - what kind of errors occur?
 		var dataErr *starknetrpc.RPCError
@@ -319,8 +400,11 @@ func (txm *starktxm) broadcast(ctx context.Context, publicKey *felt.Felt, accoun
 
 	// update nonce if transaction is successful
 	txhash = res.TransactionHash.String()
+	// watched before it can be confirmed
+	txm.watchResult(txhash, result)
 	err = txStore.AddUnconfirmed(nonce, txhash, call, publicKey)
 	if err != nil {
+		txm.takeResult(txhash)
 		return txhash, fmt.Errorf("failed to add unconfirmed tx: %+w", err)
 	}
 	return txhash, nil
@@ -362,7 +446,11 @@ func (txm *starktxm) confirmLoop() {
 						txm.lggr.Errorw("invalid felt value", "hash", hash)
 						continue
 					}
-					response, err := client.Provider.GetTransactionStatus(ctx, f)
+					var response *starknetrpc.TxnStatusResp
+					err = client.Instrument(ctx, "starknet_getTransactionStatus", func(ctx context.Context) (err error) {
+						response, err = client.Provider.GetTransactionStatus(ctx, f)
+						return err
+					})
 
 					// tx can be rejected due to a nonce error. but we cannot know from the Starknet RPC directly  so we have to wait for
 					// a broadcasted tx to fail in order to fix the nonce errors
@@ -381,6 +469,13 @@ func (txm *starktxm) confirmLoop() {
 						if err := txm.accountStore.GetTxStore(accountAddress).Confirm(unconfirmedTx.Nonce, hash); err != nil {
 							txm.lggr.Errorw("failed to confirm tx in TxStore", "hash", hash, "accountAddress", accountAddress, "error", err)
 						}
+						var resultErr error
+						if finalityStatus == starknetrpc.TxnStatus_Rejected {
+							resultErr = fmt.Errorf("transaction %s rejected", hash)
+						} else if executionStatus == starknetrpc.TxnExecutionStatusREVERTED {
+							resultErr = fmt.Errorf("transaction %s reverted", hash)
+						}
+						txm.resolve(hash, resultErr)
 					}
 
 					// currently, feeder client is only way to get rejected reason
@@ -457,6 +552,7 @@ func (txm *starktxm) resyncNonce(ctx context.Context, client *starknet.Client, a
 	}
 
 	staleTxs := txStore.SetNextNonce(rpcNonce)
+	txm.dropStale(staleTxs)
 
 	txm.lggr.Infow("resynced nonce", "accountAddress", "accountAddress", "previousNonce", currentNonce, "updatedNonce", rpcNonce, "staleTxCount", len(staleTxs))
 
@@ -467,10 +563,30 @@ func (txm *starktxm) Close() error {
 	return txm.starter.StopOnce("Txm", func() error {
 		close(txm.stop)
 		txm.done.Wait()
+		txm.dropResults()
 		return nil
 	})
 }
 
+// dropResults fails the queued and unconfirmed transactions that are waited on, so callers don't wait on a closed txm
+func (txm *starktxm) dropResults() {
+	txm.queuedLock.Lock()
+	for _, tx := range txm.queued {
+		if tx.result != nil {
+			tx.result <- TxResult{Err: errors.New("txm closed before the transaction was broadcast")}
+		}
+	}
+	txm.queued = nil
+	txm.queuedLock.Unlock()
+
+	txm.resultsLock.Lock()
+	defer txm.resultsLock.Unlock()
+	for hash, result := range txm.results {
+		result <- TxResult{Hash: hash, Err: fmt.Errorf("txm closed before transaction %s was confirmed", hash)}
+	}
+	clear(txm.results)
+}
+
 func (txm *starktxm) Healthy() error {
 	return txm.starter.Healthy()
 }
@@ -480,27 +596,157 @@ func (txm *starktxm) Ready() error {
 }
 
 func (txm *starktxm) HealthReport() map[string]error {
-	return map[string]error{txm.Name(): txm.Healthy()}
+	report := map[string]error{txm.Name(): txm.Healthy()}
+	services.CopyHealth(report, txm.deployments.healthReport(txm.Name()))
+	txm.accountsLock.RLock()
+	for address, err := range txm.keyErrs {
+		report[txm.Name()+".AccountKey."+address] = err
+	}
+	txm.accountsLock.RUnlock()
+	return report
 }
 
 func (txm *starktxm) Enqueue(ctx context.Context, accountAddress, publicKey *felt.Felt, tx starknetrpc.FunctionCall) error {
+	return txm.checkAndEnqueue(ctx, accountAddress, publicKey, tx, nil)
+}
+
+func (txm *starktxm) EnqueueWithResult(ctx context.Context, accountAddress, publicKey *felt.Felt, call starknetrpc.FunctionCall) (<-chan TxResult, error) {
+	result := make(chan TxResult, 1)
+	if err := txm.checkAndEnqueue(ctx, accountAddress, publicKey, call, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (txm *starktxm) checkAndEnqueue(ctx context.Context, accountAddress, publicKey *felt.Felt, tx starknetrpc.FunctionCall, result chan TxResult) error {
 	// validate key exists for sender
 	// use the embedded Loopp Keystore to do this; the spec and design
 	// encourage passing nil data to the loop.Keystore.Sign as way to test
 	// existence of a key
-	if _, err := txm.ks.Loopp().Sign(ctx, publicKey.String(), nil); err != nil {
-		return fmt.Errorf("enqueue: failed to sign: %+w", err)
+	for _, key := range txm.accountStrategy(accountAddress, publicKey).PublicKeys() {
+		if _, err := txm.ks.Loopp().Sign(ctx, key.String(), nil); err != nil {
+			return fmt.Errorf("enqueue: failed to sign: %+w", err)
+		}
 	}
 
+	return txm.enqueue(ctx, accountAddress, publicKey, tx, result)
+}
+
+func (txm *starktxm) enqueue(ctx context.Context, accountAddress, publicKey *felt.Felt, tx starknetrpc.FunctionCall, result chan TxResult) error {
+	queued := Tx{publicKey: publicKey, accountAddress: accountAddress, call: tx, spanContext: trace.SpanContextFromContext(ctx), result: result}
+	txm.queuedLock.Lock()
+	defer txm.queuedLock.Unlock()
+	if _, ok := txm.rotating[accountAddress.String()]; ok {
+		return fmt.Errorf("%w for account %s", ErrKeyRotation, accountAddress)
+	}
+	return txm.push(queued)
+}
+
+// push adds a call to the queue, queuedLock must be held
+func (txm *starktxm) push(queued Tx) error {
 	select {
-	case txm.queue <- Tx{publicKey: publicKey, accountAddress: accountAddress, call: tx}: // TODO fix naming here
+	case txm.queue <- queued: // TODO fix naming here
+		txm.queued = append(txm.queued, queued)
 	default:
-		return fmt.Errorf("failed to enqueue transaction: %+v", tx)
+		return fmt.Errorf("failed to enqueue transaction: %+v", queued.call)
 	}
 
 	return nil
 }
 
+// watchResult sends the outcome of a broadcast transaction to result once it is confirmed, if result is set
+func (txm *starktxm) watchResult(hash string, result chan TxResult) {
+	if result == nil {
+		return
+	}
+	txm.resultsLock.Lock()
+	defer txm.resultsLock.Unlock()
+	txm.results[hash] = result
+}
+
+func (txm *starktxm) takeResult(hash string) (chan TxResult, bool) {
+	txm.resultsLock.Lock()
+	defer txm.resultsLock.Unlock()
+	result, ok := txm.results[hash]
+	delete(txm.results, hash)
+	return result, ok
+}
+
+// resolve sends the outcome of a confirmed transaction, if it is watched
+func (txm *starktxm) resolve(hash string, err error) {
+	if result, ok := txm.takeResult(hash); ok {
+		result <- TxResult{Hash: hash, Err: err}
+	}
+}
+
+// dropStale fails the transactions no longer tracked after a nonce resync, they may still be accepted
+func (txm *starktxm) dropStale(staleTxs []*UnconfirmedTx) {
+	for _, tx := range staleTxs {
+		txm.resolve(tx.Hash, fmt.Errorf("transaction %s dropped after a nonce resync", tx.Hash))
+	}
+}
+
 func (txm *starktxm) InflightCount() (queue int, unconfirmed int) {
 	return len(txm.queue), txm.accountStore.GetTotalInflightCount()
 }
+
+func (txm *starktxm) AccountInflightCount(accountAddress *felt.Felt) (queue int, unconfirmed int) {
+	txm.queuedLock.Lock()
+	for _, tx := range txm.queued {
+		if tx.accountAddress.Equal(accountAddress) {
+			queue++
+		}
+	}
+	txm.queuedLock.Unlock()
+
+	if txStore := txm.accountStore.GetTxStore(accountAddress); txStore != nil {
+		unconfirmed = txStore.InflightCount()
+	}
+	return queue, unconfirmed
+}
+
+// dequeued removes the oldest queued call, the queue is consumed in order by the broadcast loop
+func (txm *starktxm) dequeued() {
+	txm.queuedLock.Lock()
+	defer txm.queuedLock.Unlock()
+	if len(txm.queued) > 0 {
+		txm.queued = txm.queued[1:]
+	}
+}
+
+func (txm *starktxm) PendingCalls(contractAddress *felt.Felt) (calls []starknetrpc.FunctionCall) {
+	txm.queuedLock.Lock()
+	for _, tx := range txm.queued {
+		if tx.call.ContractAddress.Equal(contractAddress) {
+			calls = append(calls, tx.call)
+		}
+	}
+	txm.queuedLock.Unlock()
+
+	for _, unconfirmedTxs := range txm.accountStore.GetAllUnconfirmed() {
+		for _, tx := range unconfirmedTxs {
+			if tx.Call.ContractAddress.Equal(contractAddress) {
+				calls = append(calls, tx.Call)
+			}
+		}
+	}
+	return calls
+}
+
+func (txm *starktxm) RegisterAccount(accountAddress *felt.Felt, account AccountStrategy) {
+	txm.accountsLock.Lock()
+	defer txm.accountsLock.Unlock()
+	txm.accounts[accountAddress.String()] = account
+	// providers register the key of their relay config, which is stale until the config is updated after a rotation
+	delete(txm.checkedKeys, accountAddress.String())
+	delete(txm.keyErrs, accountAddress.String())
+}
+
+func (txm *starktxm) accountStrategy(accountAddress, publicKey *felt.Felt) AccountStrategy {
+	txm.accountsLock.RLock()
+	defer txm.accountsLock.RUnlock()
+	if account, ok := txm.accounts[accountAddress.String()]; ok {
+		return account
+	}
+	return NewOpenZeppelinAccount(publicKey)
+}
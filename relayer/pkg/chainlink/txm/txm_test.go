@@ -77,6 +77,7 @@ func TestIntegration_Txm(t *testing.T) {
 	cfg := mocks.NewConfig(t)
 	cfg.On("TxTimeout").Return(20 * time.Second)
 	cfg.On("ConfirmationPoll").Return(1 * time.Second)
+	cfg.On("AccountClassHash").Return("")
 
 	txm, err := New(lggr, ksAdapter.Loopp(), cfg, getClient, getFeederClient)
 	require.NoError(t, err)
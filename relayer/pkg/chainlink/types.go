@@ -5,4 +5,27 @@ type RelayConfig struct {
 	ChainID        string `json:"chainID"`
 	AccountAddress string `json:"accountAddress"` // address of the account contract
 	NodeName       string `json:"nodeName"`       // optional, defaults to random node with 'chainID'
+	SignedAnswers  bool   `json:"signedAnswers"`  // optional, set for aggregators storing i128 answers
+
+	// optional, more accounts to send transmissions from, each has to be registered as a transmitter on the aggregator
+	TransmitterAccounts []TransmitterAccount `json:"transmitterAccounts"`
+	// optional, how to pick the account for a transmission: "roundRobin" (default) or "leastLoaded"
+	TransmitterSelection string `json:"transmitterSelection"`
+
+	// optional, the kind of account contract at accountAddress, see TransmitterAccount
+	AccountType       string   `json:"accountType"`
+	GuardianPublicKey string   `json:"guardianPublicKey"`
+	SignerPublicKeys  []string `json:"signerPublicKeys"`
+}
+
+type TransmitterAccount struct {
+	AccountAddress string `json:"accountAddress"`
+	PublicKey      string `json:"publicKey"` // optional, defaults to the job's transmitter key
+
+	// optional, "openzeppelin" (default), "argent" or "multisig"
+	AccountType string `json:"accountType"`
+	// optional, argent accounts only, co-signs every transaction with the owner (publicKey)
+	GuardianPublicKey string `json:"guardianPublicKey"`
+	// multisig accounts only, the signers used to meet the threshold, publicKey is not used
+	SignerPublicKeys []string `json:"signerPublicKeys"`
 }
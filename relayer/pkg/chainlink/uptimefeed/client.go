@@ -0,0 +1,95 @@
+package uptimefeed
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/ocr2"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+)
+
+//go:generate mockery --name UptimeFeedReader --output ./mocks/
+type UptimeFeedReader interface { //nolint:revive
+	LatestRoundData(context.Context, *felt.Felt) (RoundData, error)
+	L1Sender(context.Context, *felt.Felt) (common.Address, error)
+
+	BaseReader() starknet.Reader
+}
+
+var _ UptimeFeedReader = (*Client)(nil)
+
+// RoundData is a round of the sequencer uptime feed. The answer is 0 while the sequencer is up and 1 while it is down.
+// A new round only starts when the status flips, so StartedAt is the L1 timestamp of the latest status change and
+// UpdatedAt the L2 timestamp at which the status was last (re)confirmed.
+type RoundData struct {
+	ocr2.RoundData
+}
+
+// SequencerUp is true if the feed reports the sequencer as up
+func (r RoundData) SequencerUp() bool {
+	return r.Answer.Sign() == 0
+}
+
+type Client struct {
+	r    starknet.Reader
+	lggr logger.Logger
+}
+
+func NewClient(reader starknet.Reader, lggr logger.Logger) (*Client, error) {
+	return &Client{
+		r:    reader,
+		lggr: lggr,
+	}, nil
+}
+
+func (c *Client) BaseReader() starknet.Reader {
+	return c.r
+}
+
+func (c *Client) LatestRoundData(ctx context.Context, address *felt.Felt) (round RoundData, err error) {
+	ops := starknet.CallOps{
+		ContractAddress: address,
+		Selector:        starknetutils.GetSelectorFromNameFelt("latest_round_data"),
+	}
+
+	felts, err := c.r.CallContract(ctx, ops)
+	if err != nil {
+		return round, fmt.Errorf("couldn't call the contract with selector latest_round_data: %w", err)
+	}
+
+	round.RoundData, err = ocr2.NewRoundData(felts)
+	if err != nil {
+		return round, fmt.Errorf("unable to decode RoundData: %w", err)
+	}
+	return round, nil
+}
+
+func (c *Client) L1Sender(ctx context.Context, address *felt.Felt) (sender common.Address, err error) {
+	ops := starknet.CallOps{
+		ContractAddress: address,
+		Selector:        starknetutils.GetSelectorFromNameFelt("l1_sender"),
+	}
+
+	res, err := c.r.CallContract(ctx, ops)
+	if err != nil {
+		return sender, fmt.Errorf("couldn't call the contract with selector l1_sender: %w", err)
+	}
+	if len(res) != 1 {
+		return sender, fmt.Errorf("unexpected data returned from l1_sender: %v", res)
+	}
+
+	// an EthAddress is a single felt holding the 20 address bytes
+	raw := res[0].Bytes()
+	for _, b := range raw[:len(raw)-common.AddressLength] {
+		if b != 0 {
+			return sender, fmt.Errorf("l1_sender '%s' is not an ethereum address", res[0])
+		}
+	}
+	return common.BytesToAddress(raw[len(raw)-common.AddressLength:]), nil
+}
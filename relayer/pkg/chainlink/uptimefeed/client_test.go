@@ -0,0 +1,69 @@
+package uptimefeed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetutils "github.com/NethermindEth/starknet.go/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet/mocks"
+)
+
+var feedAddress = new(felt.Felt).SetUint64(0x1234)
+
+func callTo(selector string) interface{} {
+	return mock.MatchedBy(func(ops starknet.CallOps) bool {
+		return ops.ContractAddress.Equal(feedAddress) && ops.Selector.Equal(starknetutils.GetSelectorFromNameFelt(selector))
+	})
+}
+
+// round returns the latest_round_data of a feed that reported status since startedAt
+func round(status uint64, startedAt int64) []*felt.Felt {
+	return []*felt.Felt{
+		new(felt.Felt).SetUint64(2),      // round_id
+		new(felt.Felt).SetUint64(status), // answer
+		new(felt.Felt).SetUint64(100),    // block_num
+		new(felt.Felt).SetUint64(uint64(startedAt)),
+		new(felt.Felt).SetUint64(uint64(startedAt + 60)), // updated_at
+	}
+}
+
+func TestClient(t *testing.T) {
+	ctx := tests.Context(t)
+	reader := mocks.NewReader(t)
+	client, err := NewClient(reader, logger.Test(t))
+	require.NoError(t, err)
+
+	t.Run("latest round data", func(t *testing.T) {
+		reader.On("CallContract", mock.Anything, callTo("latest_round_data")).Return(round(1, 1000), nil).Once()
+		data, err := client.LatestRoundData(ctx, feedAddress)
+		require.NoError(t, err)
+		assert.False(t, data.SequencerUp())
+		assert.Equal(t, uint32(2), data.RoundID)
+		assert.Equal(t, time.Unix(1000, 0), data.StartedAt)
+		assert.Equal(t, time.Unix(1060, 0), data.UpdatedAt)
+	})
+
+	t.Run("l1 sender", func(t *testing.T) {
+		sender := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+		reader.On("CallContract", mock.Anything, callTo("l1_sender")).Return([]*felt.Felt{new(felt.Felt).SetBytes(sender.Bytes())}, nil).Once()
+		actual, err := client.L1Sender(ctx, feedAddress)
+		require.NoError(t, err)
+		assert.Equal(t, sender, actual)
+
+		tooLong, err := starknetutils.HexToFelt("0x1" + sender.Hex()[2:])
+		require.NoError(t, err)
+		reader.On("CallContract", mock.Anything, callTo("l1_sender")).Return([]*felt.Felt{tooLong}, nil).Once()
+		_, err = client.L1Sender(ctx, feedAddress)
+		assert.ErrorContains(t, err, "is not an ethereum address")
+	})
+}
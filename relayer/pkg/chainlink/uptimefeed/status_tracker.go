@@ -0,0 +1,120 @@
+package uptimefeed
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+)
+
+var _ services.Service = (*StatusTracker)(nil)
+
+// StatusTracker polls the sequencer uptime feed so transmissions can be paused while the sequencer is down.
+// Until the feed was read successfully the sequencer is assumed to be up, failing reads keep the last known status.
+type StatusTracker struct {
+	utils.StartStopOnce
+
+	address *felt.Felt
+	// read every tick, so that config updates apply to a running tracker
+	pollPeriod func() time.Duration
+	getReader  func() (starknet.Reader, error)
+	lggr       logger.Logger
+
+	lock  sync.RWMutex
+	round *RoundData
+
+	stop, done chan struct{}
+}
+
+func NewStatusTracker(address *felt.Felt, pollPeriod func() time.Duration, getReader func() (starknet.Reader, error), lggr logger.Logger) *StatusTracker {
+	return &StatusTracker{
+		address:    address,
+		pollPeriod: pollPeriod,
+		getReader:  getReader,
+		lggr:       logger.Named(lggr, "SequencerStatusTracker"),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+func (t *StatusTracker) Name() string {
+	return t.lggr.Name()
+}
+
+func (t *StatusTracker) Start(context.Context) error {
+	return t.StartOnce("SequencerStatusTracker", func() error {
+		go t.poll()
+		return nil
+	})
+}
+
+func (t *StatusTracker) Close() error {
+	return t.StopOnce("SequencerStatusTracker", func() error {
+		close(t.stop)
+		<-t.done
+		return nil
+	})
+}
+
+func (t *StatusTracker) HealthReport() map[string]error {
+	return map[string]error{t.Name(): t.Healthy()}
+}
+
+// SequencerDown is true if the latest read of the feed reported the sequencer as down
+func (t *StatusTracker) SequencerDown() bool {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.round != nil && !t.round.SequencerUp()
+}
+
+func (t *StatusTracker) poll() {
+	defer close(t.done)
+	tick := time.After(0)
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-tick:
+		}
+
+		ctx, cancel := utils.ContextFromChan(t.stop)
+		t.refresh(ctx)
+		cancel()
+
+		tick = time.After(utils.WithJitter(t.pollPeriod()))
+	}
+}
+
+func (t *StatusTracker) refresh(ctx context.Context) {
+	reader, err := t.getReader()
+	if err != nil {
+		t.lggr.Errorw("Couldn't get reader", "err", err)
+		return
+	}
+	client, err := NewClient(reader, t.lggr)
+	if err != nil {
+		t.lggr.Errorw("Couldn't create uptime feed client", "err", err)
+		return
+	}
+	round, err := client.LatestRoundData(ctx, t.address)
+	if err != nil {
+		t.lggr.Errorw("Failed to read the sequencer uptime feed", "address", t.address, "err", err)
+		return
+	}
+
+	t.lock.Lock()
+	previous := t.round
+	t.round = &round
+	t.lock.Unlock()
+
+	if previous == nil || previous.SequencerUp() != round.SequencerUp() {
+		t.lggr.Infow("Sequencer status changed", "up", round.SequencerUp(), "roundID", round.RoundID, "since", round.StartedAt)
+	}
+}
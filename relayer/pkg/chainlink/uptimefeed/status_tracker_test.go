@@ -0,0 +1,75 @@
+package uptimefeed
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet"
+	"github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet/mocks"
+)
+
+func TestStatusTracker(t *testing.T) {
+	ctx := tests.Context(t)
+	reader := mocks.NewReader(t)
+	tracker := NewStatusTracker(feedAddress, func() time.Duration { return time.Hour }, func() (starknet.Reader, error) { return reader, nil }, logger.Test(t))
+
+	t.Run("up until the feed was read", func(t *testing.T) {
+		assert.False(t, tracker.SequencerDown())
+
+		reader.On("CallContract", mock.Anything, callTo("latest_round_data")).Return(nil, assert.AnError).Once()
+		tracker.refresh(ctx)
+		assert.False(t, tracker.SequencerDown())
+	})
+
+	t.Run("down", func(t *testing.T) {
+		reader.On("CallContract", mock.Anything, callTo("latest_round_data")).Return(round(1, 1000), nil).Once()
+		tracker.refresh(ctx)
+		assert.True(t, tracker.SequencerDown())
+	})
+
+	t.Run("failed polls keep the last known status", func(t *testing.T) {
+		reader.On("CallContract", mock.Anything, callTo("latest_round_data")).Return(nil, assert.AnError).Twice()
+		tracker.refresh(ctx)
+		tracker.refresh(ctx)
+		assert.True(t, tracker.SequencerDown())
+
+		tracker.getReader = func() (starknet.Reader, error) { return nil, assert.AnError }
+		tracker.refresh(ctx)
+		assert.True(t, tracker.SequencerDown())
+		tracker.getReader = func() (starknet.Reader, error) { return reader, nil }
+	})
+
+	t.Run("up", func(t *testing.T) {
+		reader.On("CallContract", mock.Anything, callTo("latest_round_data")).Return(round(0, 2000), nil).Once()
+		tracker.refresh(ctx)
+		assert.False(t, tracker.SequencerDown())
+	})
+}
+
+func TestStatusTracker_Poll(t *testing.T) {
+	reader := mocks.NewReader(t)
+	// the period is read every tick, like the config it comes from
+	var periodReads atomic.Int32
+	pollPeriod := func() time.Duration {
+		periodReads.Add(1)
+		return 10 * time.Millisecond
+	}
+	tracker := NewStatusTracker(feedAddress, pollPeriod, func() (starknet.Reader, error) { return reader, nil }, logger.Test(t))
+
+	// the sequencer goes down after the first poll
+	reader.On("CallContract", mock.Anything, callTo("latest_round_data")).Return(round(0, 1000), nil).Once()
+	reader.On("CallContract", mock.Anything, callTo("latest_round_data")).Return(round(1, 2000), nil)
+
+	require.NoError(t, tracker.Start(tests.Context(t)))
+	require.Eventually(t, func() bool { return periodReads.Load() >= 3 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, tracker.Close())
+	assert.True(t, tracker.SequencerDown())
+}
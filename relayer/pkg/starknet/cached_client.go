@@ -0,0 +1,156 @@
+package starknet
+
+import (
+	"context"
+	"strings"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+)
+
+// ReaderChainClient is a client serving both Reader and ChainClient requests, like Client
+type ReaderChainClient interface {
+	Reader
+	ChainClient
+}
+
+var _ ReaderChainClient = (*CachedClient)(nil)
+
+// CachedClient caches the responses that can't change: blocks and receipts once accepted on L1, calls at a block hash
+// and transactions. Blocks and receipts that are still pending or only accepted on L2 are passed through, their status
+// changes and L2 blocks can still be reorged. Requests for the latest or pending block, or by block number, are passed
+// through, as are batches.
+//
+// Cached responses are shared between callers, which must not modify them.
+type CachedClient struct {
+	ReaderChainClient
+
+	blocks          *lru[felt.Felt, *starknetrpc.Block]
+	finalizedBlocks *lru[felt.Felt, FinalizedBlock]
+	calls           *lru[string, []*felt.Felt]
+	transactions    *lru[felt.Felt, starknetrpc.Transaction]
+	receipts        *lru[felt.Felt, starknetrpc.TransactionReceipt]
+}
+
+// NewCachedClient caches the responses of client, keeping up to size responses of each request type
+func NewCachedClient(client ReaderChainClient, size int) *CachedClient {
+	return &CachedClient{
+		ReaderChainClient: client,
+		blocks:            newLRU[felt.Felt, *starknetrpc.Block](size),
+		finalizedBlocks:   newLRU[felt.Felt, FinalizedBlock](size),
+		calls:             newLRU[string, []*felt.Felt](size),
+		transactions:      newLRU[felt.Felt, starknetrpc.Transaction](size),
+		receipts:          newLRU[felt.Felt, starknetrpc.TransactionReceipt](size),
+	}
+}
+
+func (c *CachedClient) BlockWithTxHashes(ctx context.Context, blockID starknetrpc.BlockID) (*starknetrpc.Block, error) {
+	if !isBlockHash(blockID) {
+		return c.ReaderChainClient.BlockWithTxHashes(ctx, blockID)
+	}
+	if block, ok := c.blocks.Get(*blockID.Hash); ok {
+		return block, nil
+	}
+	block, err := c.ReaderChainClient.BlockWithTxHashes(ctx, blockID)
+	if err == nil && block != nil && block.Status == starknetrpc.BlockStatus_AcceptedOnL1 {
+		c.blocks.Add(*blockID.Hash, block)
+	}
+	return block, err
+}
+
+func (c *CachedClient) BlockByHash(ctx context.Context, h *felt.Felt) (FinalizedBlock, error) {
+	if block, ok := c.finalizedBlocks.Get(*h); ok {
+		return block, nil
+	}
+	block, err := c.ReaderChainClient.BlockByHash(ctx, h)
+	if err == nil && block.Status == starknetrpc.BlockStatus_AcceptedOnL1 {
+		c.finalizedBlocks.Add(*h, block)
+	}
+	return block, err
+}
+
+func (c *CachedClient) Call(ctx context.Context, call starknetrpc.FunctionCall, blockID starknetrpc.BlockID) ([]*felt.Felt, error) {
+	if !isBlockHash(blockID) {
+		return c.ReaderChainClient.Call(ctx, call, blockID)
+	}
+	key := callKey(call, *blockID.Hash)
+	if out, ok := c.calls.Get(key); ok {
+		return out, nil
+	}
+	out, err := c.ReaderChainClient.Call(ctx, call, blockID)
+	if err == nil {
+		c.calls.Add(key, out)
+	}
+	return out, err
+}
+
+func (c *CachedClient) TransactionByHash(ctx context.Context, hash *felt.Felt) (starknetrpc.Transaction, error) {
+	if tx, ok := c.transactions.Get(*hash); ok {
+		return tx, nil
+	}
+	tx, err := c.ReaderChainClient.TransactionByHash(ctx, hash)
+	if err == nil {
+		c.transactions.Add(*hash, tx)
+	}
+	return tx, err
+}
+
+func (c *CachedClient) TransactionReceipt(ctx context.Context, hash *felt.Felt) (starknetrpc.TransactionReceipt, error) {
+	if receipt, ok := c.receipts.Get(*hash); ok {
+		return receipt, nil
+	}
+	receipt, err := c.ReaderChainClient.TransactionReceipt(ctx, hash)
+	if err == nil && c.acceptedOnL1(ctx, receipt) {
+		c.receipts.Add(*hash, receipt)
+	}
+	return receipt, err
+}
+
+// acceptedOnL1 reports whether the transaction of the receipt is accepted on L1. The receipts returned by the RPC
+// provider don't decode the receipt itself, only its block, so the status of the block is checked for them.
+func (c *CachedClient) acceptedOnL1(ctx context.Context, receipt starknetrpc.TransactionReceipt) bool {
+	withBlock, ok := receipt.(*starknetrpc.TransactionReceiptWithBlockInfo)
+	if !ok || withBlock.TransactionReceipt != nil {
+		return finalityStatus(receipt) == starknetrpc.TxnFinalityStatusAcceptedOnL1
+	}
+	// receipts of pending transactions don't have a block hash yet
+	if withBlock.BlockHash == nil {
+		return false
+	}
+	block, err := c.BlockByHash(ctx, withBlock.BlockHash)
+	return err == nil && block.Status == starknetrpc.BlockStatus_AcceptedOnL1
+}
+
+// finalityStatus returns the finality status of a receipt, or an empty status for unknown receipt types
+func finalityStatus(receipt starknetrpc.TransactionReceipt) starknetrpc.TxnFinalityStatus {
+	if withBlock, ok := receipt.(*starknetrpc.TransactionReceiptWithBlockInfo); ok {
+		receipt = withBlock.TransactionReceipt
+	}
+	switch r := receipt.(type) {
+	case starknetrpc.InvokeTransactionReceipt:
+		return r.FinalityStatus
+	case starknetrpc.DeclareTransactionReceipt:
+		return r.FinalityStatus
+	case starknetrpc.DeployTransactionReceipt:
+		return r.FinalityStatus
+	case starknetrpc.DeployAccountTransactionReceipt:
+		return r.FinalityStatus
+	case starknetrpc.L1HandlerTransactionReceipt:
+		return r.FinalityStatus
+	}
+	return ""
+}
+
+// isBlockHash reports whether blockID is a block hash, a tag is sent instead of the hash if both are set
+func isBlockHash(blockID starknetrpc.BlockID) bool {
+	return blockID.Hash != nil && blockID.Tag == ""
+}
+
+func callKey(call starknetrpc.FunctionCall, blockHash felt.Felt) string {
+	var key strings.Builder
+	for _, f := range append([]*felt.Felt{&blockHash, call.ContractAddress, call.EntryPointSelector}, call.Calldata...) {
+		key.WriteString(f.String())
+		key.WriteByte(',')
+	}
+	return key.String()
+}
@@ -0,0 +1,118 @@
+package starknet
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func TestLRU(t *testing.T) {
+	c := newLRU[int, string](2)
+	c.Add(1, "a")
+	c.Add(2, "b")
+	_, ok := c.Get(1) // 2 is now the least recently used
+	require.True(t, ok)
+	c.Add(3, "c")
+
+	assert.Equal(t, 2, c.Len())
+	_, ok = c.Get(2)
+	assert.False(t, ok)
+	v, ok := c.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "a", v)
+}
+
+func TestCachedClient(t *testing.T) {
+	var lock sync.Mutex
+	requests := map[string]int{}
+	// blocks and receipts are only cached once accepted on L1
+	status := "ACCEPTED_ON_L2"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var call struct {
+			Method string `json:"method"`
+		}
+		require.NoError(t, json.Unmarshal(body, &call))
+		lock.Lock()
+		requests[call.Method]++
+		status := status
+		lock.Unlock()
+
+		var result string
+		switch call.Method {
+		case "starknet_getBlockWithTxs":
+			result = `{"status": "` + status + `", "block_hash": "0x1", "block_number": 1, "transactions": []}`
+		case "starknet_call":
+			result = `["0x2"]`
+		case "starknet_getTransactionReceipt":
+			result = `{"type": "INVOKE", "transaction_hash": "0x3", "block_hash": "0x1", "block_number": 1, "finality_status": "` + status + `", "execution_status": "SUCCEEDED", "actual_fee": {"amount": "0x0", "unit": "WEI"}}`
+		default:
+			require.Fail(t, "unsupported RPC method", call.Method)
+		}
+		_, err = w.Write([]byte(`{"jsonrpc": "2.0", "id": 1, "result": ` + result + `}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(chainID, server.URL, "", logger.Test(t), &timeout)
+	require.NoError(t, err)
+	cached := NewCachedClient(client, 10)
+	ctx := tests.Context(t)
+	hash := new(felt.Felt).SetUint64(1)
+	call := starknetrpc.FunctionCall{ContractAddress: hash, EntryPointSelector: hash}
+
+	fetch := func() {
+		block, err := cached.BlockByHash(ctx, hash)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), block.BlockNumber)
+
+		_, err = cached.BlockByNumber(ctx, 1)
+		require.NoError(t, err)
+
+		out, err := cached.Call(ctx, call, starknetrpc.BlockID{Hash: hash})
+		require.NoError(t, err)
+		assert.Equal(t, []*felt.Felt{new(felt.Felt).SetUint64(2)}, out)
+
+		_, err = cached.Call(ctx, call, starknetrpc.BlockID{Tag: "pending"})
+		require.NoError(t, err)
+
+		receipt, err := cached.TransactionReceipt(ctx, hash)
+		require.NoError(t, err)
+		require.IsType(t, &starknetrpc.TransactionReceiptWithBlockInfo{}, receipt)
+		assert.Equal(t, hash, receipt.(*starknetrpc.TransactionReceiptWithBlockInfo).BlockHash)
+	}
+
+	// calls at a block hash are sent once, blocks and receipts on L2 and requests by number or tag every time.
+	// The status of a receipt is the one of its block.
+	fetch()
+	fetch()
+	assert.Equal(t, map[string]int{
+		"starknet_getBlockWithTxs":       6,
+		"starknet_call":                  3,
+		"starknet_getTransactionReceipt": 2,
+	}, requests)
+
+	lock.Lock()
+	status = "ACCEPTED_ON_L1"
+	lock.Unlock()
+	for i := 0; i < 3; i++ {
+		fetch()
+	}
+	assert.Equal(t, map[string]int{
+		"starknet_getBlockWithTxs":       10,
+		"starknet_call":                  6,
+		"starknet_getTransactionReceipt": 3,
+	}, requests)
+}
@@ -0,0 +1,84 @@
+package starknet
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+)
+
+// InvokeCalldata returns the account __execute__ calldata of an invoke transaction
+func InvokeCalldata(tx starknetrpc.Transaction) ([]*felt.Felt, error) {
+	switch tx := tx.(type) {
+	case starknetrpc.InvokeTxnV1:
+		return tx.Calldata, nil
+	case *starknetrpc.InvokeTxnV1:
+		return tx.Calldata, nil
+	case starknetrpc.InvokeTxnV3:
+		return tx.Calldata, nil
+	case *starknetrpc.InvokeTxnV3:
+		return tx.Calldata, nil
+	default:
+		return nil, fmt.Errorf("not an invoke transaction: %T", tx)
+	}
+}
+
+// InvokeActualFee returns the fee paid by an invoke transaction
+func InvokeActualFee(receipt starknetrpc.TransactionReceipt) (starknetrpc.FeePayment, error) {
+	switch receipt := receipt.(type) {
+	case starknetrpc.InvokeTransactionReceipt:
+		return receipt.ActualFee, nil
+	case *starknetrpc.InvokeTransactionReceipt:
+		return receipt.ActualFee, nil
+	default:
+		return starknetrpc.FeePayment{}, fmt.Errorf("not an invoke transaction receipt: %T", receipt)
+	}
+}
+
+// ParseExecuteCalldata decodes Cairo 2 account __execute__ calldata, as built by the txm:
+// [calls_len, to, selector, calldata_len, calldata..., to, selector, calldata_len, calldata..., ...]
+func ParseExecuteCalldata(calldata []*felt.Felt) ([]starknetrpc.FunctionCall, error) {
+	if len(calldata) == 0 {
+		return nil, errors.New("empty calldata")
+	}
+	n, ok := feltToLength(calldata[0], len(calldata))
+	if !ok {
+		return nil, fmt.Errorf("invalid number of calls: %s", calldata[0])
+	}
+
+	calls := make([]starknetrpc.FunctionCall, 0, n)
+	index := 1
+	for i := 0; i < n; i++ {
+		if len(calldata) < index+3 {
+			return nil, fmt.Errorf("call %d: calldata too short", i)
+		}
+		to, selector, length := calldata[index], calldata[index+1], calldata[index+2]
+		index += 3
+		l, ok := feltToLength(length, len(calldata)-index)
+		if !ok {
+			return nil, fmt.Errorf("call %d: invalid calldata length: %s", i, length)
+		}
+		end := index + l
+		calls = append(calls, starknetrpc.FunctionCall{
+			ContractAddress:    to,
+			EntryPointSelector: selector,
+			Calldata:           calldata[index:end],
+		})
+		index = end
+	}
+	if index != len(calldata) {
+		return nil, fmt.Errorf("unexpected %d trailing felts after %d calls", len(calldata)-index, n)
+	}
+	return calls, nil
+}
+
+// feltToLength converts an array length felt, bounded by the number of felts that are left
+func feltToLength(f *felt.Felt, limit int) (int, bool) {
+	l := f.BigInt(big.NewInt(0))
+	if !l.IsInt64() || l.Int64() > int64(limit) {
+		return 0, false
+	}
+	return int(l.Int64()), true
+}
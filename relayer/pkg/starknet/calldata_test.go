@@ -0,0 +1,67 @@
+package starknet
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starknetaccount "github.com/NethermindEth/starknet.go/account"
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExecuteCalldata(t *testing.T) {
+	calls := []starknetrpc.FunctionCall{
+		{
+			ContractAddress:    new(felt.Felt).SetUint64(1),
+			EntryPointSelector: new(felt.Felt).SetUint64(2),
+			Calldata:           []*felt.Felt{new(felt.Felt).SetUint64(3), new(felt.Felt).SetUint64(4)},
+		},
+		{
+			ContractAddress:    new(felt.Felt).SetUint64(5),
+			EntryPointSelector: new(felt.Felt).SetUint64(6),
+			Calldata:           []*felt.Felt{},
+		},
+	}
+	calldata := starknetaccount.FmtCallDataCairo2(calls)
+
+	tx := starknetrpc.InvokeTxnV1{Calldata: calldata}
+	invokeCalldata, err := InvokeCalldata(tx)
+	require.NoError(t, err)
+
+	parsed, err := ParseExecuteCalldata(invokeCalldata)
+	require.NoError(t, err)
+	assert.Equal(t, calls, parsed)
+
+	_, err = InvokeCalldata(starknetrpc.L1HandlerTxn{})
+	assert.ErrorContains(t, err, "not an invoke transaction")
+
+	_, err = ParseExecuteCalldata(nil)
+	assert.ErrorContains(t, err, "empty calldata")
+
+	_, err = ParseExecuteCalldata(calldata[:len(calldata)-1])
+	assert.ErrorContains(t, err, "call 1: calldata too short")
+
+	_, err = ParseExecuteCalldata(append(calldata, new(felt.Felt)))
+	assert.ErrorContains(t, err, "1 trailing felts")
+
+	bad := append([]*felt.Felt{}, calldata...)
+	bad[3] = new(felt.Felt).SetUint64(100)
+	_, err = ParseExecuteCalldata(bad)
+	assert.ErrorContains(t, err, "call 0: invalid calldata length")
+}
+
+func TestInvokeActualFee(t *testing.T) {
+	fee := starknetrpc.FeePayment{Amount: new(felt.Felt).SetUint64(42), Unit: starknetrpc.UnitStrk}
+
+	actual, err := InvokeActualFee(starknetrpc.InvokeTransactionReceipt{ActualFee: fee})
+	require.NoError(t, err)
+	assert.Equal(t, fee, actual)
+
+	actual, err = InvokeActualFee(&starknetrpc.InvokeTransactionReceipt{ActualFee: fee})
+	require.NoError(t, err)
+	assert.Equal(t, fee, actual)
+
+	_, err = InvokeActualFee(starknetrpc.DeclareTransactionReceipt{ActualFee: fee})
+	assert.ErrorContains(t, err, "not an invoke transaction receipt")
+}
@@ -20,6 +20,7 @@ type BatchBuilder interface {
 	// RequestLatestPendingBlock() (BatchBuilder)
 	RequestLatestBlockHashAndNumber() BatchBuilder
 	RequestEventsByFilter(f starknetrpc.EventsInput) BatchBuilder
+	RequestCall(call starknetrpc.FunctionCall, block starknetrpc.BlockID) BatchBuilder
 	// RequestTxReceiptByHash(h *felt.Felt) (BatchBuilder)
 	Build() []gethrpc.BatchElem
 }
@@ -39,6 +40,15 @@ func (b *batchBuilder) RequestEventsByFilter(e starknetrpc.EventsInput) BatchBui
 	return b
 }
 
+func (b *batchBuilder) RequestCall(call starknetrpc.FunctionCall, block starknetrpc.BlockID) BatchBuilder {
+	b.args = append(b.args, gethrpc.BatchElem{
+		Method: "starknet_call",
+		Args:   []interface{}{call, block},
+		Result: &[]*felt.Felt{},
+	})
+	return b
+}
+
 func NewBatchBuilder() BatchBuilder {
 	return &batchBuilder{
 		args: nil,
@@ -135,38 +145,22 @@ func (c *Client) ChainID(ctx context.Context) (string, error) {
 }
 
 func (c *Client) BlockByHash(ctx context.Context, h *felt.Felt) (FinalizedBlock, error) {
-	if c.defaultTimeout != 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, c.defaultTimeout)
-		defer cancel()
-	}
-
-	block, err := c.Provider.BlockWithTxs(ctx, starknetrpc.BlockID{Hash: h})
-
-	if err != nil {
-		return FinalizedBlock{}, fmt.Errorf("error in BlockByHash: %w", err)
-	}
-
-	finalizedBlock, ok := block.(*FinalizedBlock)
-
-	if !ok {
-		return FinalizedBlock{}, fmt.Errorf("expected type Finalized block but found: %T", block)
-	}
-
-	return *finalizedBlock, nil
+	return c.blockWithTxs(ctx, starknetrpc.BlockID{Hash: h}, "BlockByHash")
 }
 
 func (c *Client) BlockByNumber(ctx context.Context, id uint64) (FinalizedBlock, error) {
-	if c.defaultTimeout != 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, c.defaultTimeout)
-		defer cancel()
-	}
+	return c.blockWithTxs(ctx, starknetrpc.BlockID{Number: &id}, "BlockByNumber")
+}
 
-	block, err := c.Provider.BlockWithTxs(ctx, starknetrpc.BlockID{Number: &id})
+func (c *Client) blockWithTxs(ctx context.Context, blockID starknetrpc.BlockID, method string) (FinalizedBlock, error) {
+	var block interface{}
+	err := c.do(ctx, "starknet_getBlockWithTxs", func(ctx context.Context) (err error) {
+		block, err = c.Provider.BlockWithTxs(ctx, blockID)
+		return err
+	})
 
 	if err != nil {
-		return FinalizedBlock{}, fmt.Errorf("error in BlockByNumber: %w", err)
+		return FinalizedBlock{}, fmt.Errorf("error in %s: %w", method, err)
 	}
 
 	finalizedBlock, ok := block.(*FinalizedBlock)
@@ -179,13 +173,11 @@ func (c *Client) BlockByNumber(ctx context.Context, id uint64) (FinalizedBlock,
 }
 
 func (c *Client) LatestBlockHashAndNumber(ctx context.Context) (starknetrpc.BlockHashAndNumberOutput, error) {
-	if c.defaultTimeout != 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, c.defaultTimeout)
-		defer cancel()
-	}
-
-	info, err := c.Provider.BlockHashAndNumber(ctx)
+	var info *starknetrpc.BlockHashAndNumberOutput
+	err := c.do(ctx, "starknet_blockHashAndNumber", func(ctx context.Context) (err error) {
+		info, err = c.Provider.BlockHashAndNumber(ctx)
+		return err
+	})
 	if err != nil {
 		return starknetrpc.BlockHashAndNumberOutput{}, fmt.Errorf("error in LatestBlockHashAndNumber: %w", err)
 	}
@@ -194,13 +186,11 @@ func (c *Client) LatestBlockHashAndNumber(ctx context.Context) (starknetrpc.Bloc
 }
 
 func (c *Client) EventsByFilter(ctx context.Context, f starknetrpc.EventsInput) (starknetrpc.EventChunk, error) {
-	if c.defaultTimeout != 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, c.defaultTimeout)
-		defer cancel()
-	}
-
-	chunk, err := c.Provider.Events(ctx, f)
+	var chunk *starknetrpc.EventChunk
+	err := c.do(ctx, "starknet_getEvents", func(ctx context.Context) (err error) {
+		chunk, err = c.Provider.Events(ctx, f)
+		return err
+	})
 
 	if err != nil {
 		return starknetrpc.EventChunk{}, fmt.Errorf("error in EventsByFilter: %w", err)
@@ -209,16 +199,13 @@ func (c *Client) EventsByFilter(ctx context.Context, f starknetrpc.EventsInput)
 	return *chunk, nil
 }
 
+// Batch retries the whole batch if the request failed, errors of single requests are returned in their BatchElem
 func (c *Client) Batch(ctx context.Context, builder BatchBuilder) ([]gethrpc.BatchElem, error) {
-	if c.defaultTimeout != 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, c.defaultTimeout)
-		defer cancel()
-	}
-
 	args := builder.Build()
 
-	err := c.EthClient.BatchCallContext(ctx, args)
+	err := c.do(ctx, "batch", func(ctx context.Context) error {
+		return c.EthClient.BatchCallContext(ctx, args)
+	})
 
 	if err != nil {
 		return nil, fmt.Errorf("error in Batch: %w", err)
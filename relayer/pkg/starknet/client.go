@@ -3,6 +3,9 @@ package starknet
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
 	"strings"
 	"time"
 
@@ -26,6 +29,7 @@ type Reader interface {
 	TransactionByHash(context.Context, *felt.Felt) (starknetrpc.Transaction, error)
 	TransactionReceipt(context.Context, *felt.Felt) (starknetrpc.TransactionReceipt, error)
 	AccountNonce(context.Context, *felt.Felt) (*felt.Felt, error)
+	StorageAt(ctx context.Context, contractAddress *felt.Felt, variable string) (*felt.Felt, error)
 }
 
 type Writer interface {
@@ -45,31 +49,92 @@ type Client struct {
 	EthClient      *ethrpc.Client
 	lggr           logger.Logger
 	defaultTimeout time.Duration
+	retryPolicy    RetryPolicy
+	// labels of the metrics and spans of the requests
+	chainID string
+	node    string
+}
+
+type clientOptions struct {
+	headers     map[string]string
+	limiter     *RateLimiter
+	retryPolicy RetryPolicy
+	node        string
+}
+
+// ClientOption configures optional behaviour of a Client
+type ClientOption func(*clientOptions)
+
+// WithHeaders sends the headers with every request, for nodes authenticating with other headers than x-apikey
+func WithHeaders(headers map[string]string) ClientOption {
+	return func(o *clientOptions) {
+		o.headers = headers
+	}
+}
+
+// WithRateLimiter limits the requests of the client, the limiter can be shared by the clients of a node. It applies
+// to every HTTP request, so to every Reader and ChainClient call and to each of their retries.
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(o *clientOptions) {
+		o.limiter = limiter
+	}
+}
+
+// WithNodeName sets the node name in the metrics and spans of the requests, by default it is the host of the node URL
+func WithNodeName(name string) ClientOption {
+	return func(o *clientOptions) {
+		o.node = name
+	}
 }
 
 // pass nil or 0 to timeout to not use built in default timeout
-func NewClient(chainID string, baseURL string, apiKey string, lggr logger.Logger, timeout *time.Duration) (*Client, error) {
-	// TODO: chainID now unused
+func NewClient(chainID string, baseURL string, apiKey string, lggr logger.Logger, timeout *time.Duration, opts ...ClientOption) (*Client, error) {
+	var o clientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.node == "" {
+		if u, err := url.Parse(baseURL); err == nil {
+			o.node = u.Host
+		}
+	}
 
-	options := []ethrpc.ClientOption{}
+	var transport http.RoundTripper = http.DefaultTransport
+	if o.limiter != nil {
+		transport = &rateLimitedTransport{limiter: o.limiter, next: transport}
+	}
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	options := []ethrpc.ClientOption{ethrpc.WithHTTPClient(&http.Client{
+		Jar:       jar,
+		Transport: &instrumentedTransport{chainID: chainID, node: o.node, next: transport},
+	})}
 	if strings.TrimSpace(apiKey) != "" {
 		options = append(options, ethrpc.WithHeader("x-apikey", apiKey))
 	}
+	for name, value := range o.headers {
+		options = append(options, ethrpc.WithHeader(name, value))
+	}
 
 	provider, err := starknetrpc.NewProvider(baseURL, options...)
 	if err != nil {
 		return nil, err
 	}
 
-	c, err := ethrpc.DialContext(context.Background(), baseURL)
+	c, err := ethrpc.DialOptions(context.Background(), baseURL, options...)
 	if err != nil {
 		return nil, err
 	}
 
 	client := &Client{
-		Provider:  provider,
-		EthClient: c,
-		lggr:      lggr,
+		Provider:    provider,
+		EthClient:   c,
+		lggr:        lggr,
+		retryPolicy: o.retryPolicy,
+		chainID:     chainID,
+		node:        o.node,
 	}
 
 	// make copy to preserve value
@@ -100,14 +165,11 @@ func (c *Client) CallContract(ctx context.Context, ops CallOps) (data []*felt.Fe
 	return res, nil
 }
 
-func (c *Client) LatestBlockHeight(ctx context.Context) (uint64, error) {
-	if c.defaultTimeout != 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, c.defaultTimeout)
-		defer cancel()
-	}
-
-	blockNum, err := c.Provider.BlockNumber(ctx)
+func (c *Client) LatestBlockHeight(ctx context.Context) (blockNum uint64, err error) {
+	err = c.do(ctx, "starknet_blockNumber", func(ctx context.Context) (err error) {
+		blockNum, err = c.Provider.BlockNumber(ctx)
+		return err
+	})
 	if err != nil {
 		return 0, fmt.Errorf("error in client.LatestBlockHeight: %w", err)
 	}
@@ -118,27 +180,26 @@ func (c *Client) LatestBlockHeight(ctx context.Context) (uint64, error) {
 // -- caigo.Provider interface --
 
 func (c *Client) BlockWithTxHashes(ctx context.Context, blockID starknetrpc.BlockID) (*starknetrpc.Block, error) {
-	if c.defaultTimeout != 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, c.defaultTimeout)
-		defer cancel()
-	}
-
-	out, err := c.Provider.BlockWithTxHashes(ctx, blockID)
+	var out interface{}
+	err := c.do(ctx, "starknet_getBlockWithTxHashes", func(ctx context.Context) (err error) {
+		out, err = c.Provider.BlockWithTxHashes(ctx, blockID)
+		return err
+	})
 	if err != nil {
-		return out.(*starknetrpc.Block), fmt.Errorf("error in client.BlockWithTxHashes: %w", err)
+		return nil, fmt.Errorf("error in client.BlockWithTxHashes: %w", err)
 	}
-	return out.(*starknetrpc.Block), nil
-}
-
-func (c *Client) Call(ctx context.Context, calls starknetrpc.FunctionCall, blockHashOrTag starknetrpc.BlockID) ([]*felt.Felt, error) {
-	if c.defaultTimeout != 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, c.defaultTimeout)
-		defer cancel()
+	block, ok := out.(*starknetrpc.Block)
+	if !ok {
+		return nil, fmt.Errorf("error in client.BlockWithTxHashes: expected type Block but found: %T", out)
 	}
+	return block, nil
+}
 
-	out, err := c.Provider.Call(ctx, calls, blockHashOrTag)
+func (c *Client) Call(ctx context.Context, calls starknetrpc.FunctionCall, blockHashOrTag starknetrpc.BlockID) (out []*felt.Felt, err error) {
+	err = c.do(ctx, "starknet_call", func(ctx context.Context) (err error) {
+		out, err = c.Provider.Call(ctx, calls, blockHashOrTag)
+		return err
+	})
 	if err != nil {
 		return out, fmt.Errorf("error in client.Call: %w", err)
 	}
@@ -148,14 +209,11 @@ func (c *Client) Call(ctx context.Context, calls starknetrpc.FunctionCall, block
 	return out, nil
 }
 
-func (c *Client) TransactionByHash(ctx context.Context, hash *felt.Felt) (starknetrpc.Transaction, error) {
-	if c.defaultTimeout != 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, c.defaultTimeout)
-		defer cancel()
-	}
-
-	out, err := c.Provider.TransactionByHash(ctx, hash)
+func (c *Client) TransactionByHash(ctx context.Context, hash *felt.Felt) (out starknetrpc.Transaction, err error) {
+	err = c.do(ctx, "starknet_getTransactionByHash", func(ctx context.Context) (err error) {
+		out, err = c.Provider.TransactionByHash(ctx, hash)
+		return err
+	})
 	if err != nil {
 		return out, fmt.Errorf("error in client.TransactionByHash: %w", err)
 	}
@@ -165,14 +223,11 @@ func (c *Client) TransactionByHash(ctx context.Context, hash *felt.Felt) (starkn
 	return out, nil
 }
 
-func (c *Client) TransactionReceipt(ctx context.Context, hash *felt.Felt) (starknetrpc.TransactionReceipt, error) {
-	if c.defaultTimeout != 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, c.defaultTimeout)
-		defer cancel()
-	}
-
-	out, err := c.Provider.TransactionReceipt(ctx, hash)
+func (c *Client) TransactionReceipt(ctx context.Context, hash *felt.Felt) (out starknetrpc.TransactionReceipt, err error) {
+	err = c.do(ctx, "starknet_getTransactionReceipt", func(ctx context.Context) (err error) {
+		out, err = c.Provider.TransactionReceipt(ctx, hash)
+		return err
+	})
 	if err != nil {
 		return out, fmt.Errorf("error in client.TransactionReceipt: %w", err)
 	}
@@ -182,14 +237,11 @@ func (c *Client) TransactionReceipt(ctx context.Context, hash *felt.Felt) (stark
 	return out, nil
 }
 
-func (c *Client) Events(ctx context.Context, input starknetrpc.EventsInput) (*starknetrpc.EventChunk, error) {
-	if c.defaultTimeout != 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, c.defaultTimeout)
-		defer cancel()
-	}
-
-	out, err := c.Provider.Events(ctx, input)
+func (c *Client) Events(ctx context.Context, input starknetrpc.EventsInput) (out *starknetrpc.EventChunk, err error) {
+	err = c.do(ctx, "starknet_getEvents", func(ctx context.Context) (err error) {
+		out, err = c.Provider.Events(ctx, input)
+		return err
+	})
 	if err != nil {
 		return out, fmt.Errorf("error in client.Events: %w", err)
 	}
@@ -199,12 +251,27 @@ func (c *Client) Events(ctx context.Context, input starknetrpc.EventsInput) (*st
 	return out, nil
 }
 
-func (c *Client) AccountNonce(ctx context.Context, accountAddress *felt.Felt) (*felt.Felt, error) {
-	if c.defaultTimeout != 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, c.defaultTimeout)
-		defer cancel()
-	}
+func (c *Client) AccountNonce(ctx context.Context, accountAddress *felt.Felt) (nonce *felt.Felt, err error) {
+	err = c.do(ctx, "starknet_getNonce", func(ctx context.Context) (err error) {
+		nonce, err = c.Provider.Nonce(ctx, starknetrpc.BlockID{Tag: "pending"}, accountAddress)
+		return err
+	})
+	return nonce, err
+}
 
-	return c.Provider.Nonce(ctx, starknetrpc.BlockID{Tag: "pending"}, accountAddress)
+// StorageAt reads a storage variable without a getter, variable is its name in the contract
+func (c *Client) StorageAt(ctx context.Context, contractAddress *felt.Felt, variable string) (*felt.Felt, error) {
+	var out string
+	err := c.do(ctx, "starknet_getStorageAt", func(ctx context.Context) (err error) {
+		out, err = c.Provider.StorageAt(ctx, contractAddress, variable, starknetrpc.BlockID{Tag: "pending"})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error in client.StorageAt: %w", err)
+	}
+	value, err := new(felt.Felt).SetString(out)
+	if err != nil {
+		return nil, fmt.Errorf("error in client.StorageAt: invalid value %s: %w", out, err)
+	}
+	return value, nil
 }
@@ -35,6 +35,7 @@ type FeederClient struct {
 	maxWait    time.Duration
 	minWait    time.Duration
 	log        utils.SimpleLogger
+	chainID    string
 }
 
 func (c *FeederClient) WithBackoff(b Backoff) *FeederClient {
@@ -62,6 +63,12 @@ func (c *FeederClient) WithLogger(log utils.SimpleLogger) *FeederClient {
 	return c
 }
 
+// WithChainID sets the chain ID in the metrics and spans of the requests
+func (c *FeederClient) WithChainID(chainID string) *FeederClient {
+	c.chainID = chainID
+	return c
+}
+
 func ExponentialBackoff(wait time.Duration) time.Duration {
 	return wait * 2
 }
@@ -141,7 +148,12 @@ func (c *FeederClient) get(ctx context.Context, queryURL string) (io.ReadCloser,
 	return nil, err
 }
 
-func (c *FeederClient) TransactionFailure(ctx context.Context, transactionHash *felt.Felt) (*TransactionFailureReason, error) {
+func (c *FeederClient) TransactionFailure(ctx context.Context, transactionHash *felt.Felt) (_ *TransactionFailureReason, err error) {
+	const method = "get_transaction"
+	ctx, span := startSpan(ctx, c.chainID, feederNode, method)
+	defer func() { endSpan(span, err) }()
+	defer func(start time.Time) { observeRequest(c.chainID, feederNode, method, start, err) }(time.Now())
+
 	queryURL := c.buildQueryString("get_transaction", map[string]string{
 		"transactionHash": transactionHash.String(),
 	})
@@ -0,0 +1,156 @@
+package starknet
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// statuses of RPC requests
+const (
+	statusSuccess = "success"
+	// the request can succeed when sent again, see IsRetryable
+	statusRetryableError = "retryable_error"
+	statusError          = "error"
+)
+
+// methodOther labels the payloads of requests sent through the Provider instead of a Client method
+const methodOther = "other"
+
+// feederNode labels the requests to the feeder gateway
+const feederNode = "feeder"
+
+var (
+	promRPCRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "starknet_rpc_requests",
+		Help: "Number of requests sent to Starknet nodes, each retry is a request",
+	}, []string{"chain_id", "node", "method", "status"})
+	promRPCRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "starknet_rpc_request_duration_seconds",
+		Help:    "Duration of requests sent to Starknet nodes",
+		Buckets: prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"chain_id", "node", "method"})
+	promRPCRequestSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "starknet_rpc_request_size_bytes",
+		Help:    "Size of the HTTP request bodies sent to Starknet nodes",
+		Buckets: prometheus.ExponentialBuckets(128, 4, 8),
+	}, []string{"chain_id", "node", "method"})
+	promRPCResponseSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "starknet_rpc_response_size_bytes",
+		Help:    "Size of the HTTP response bodies received from Starknet nodes",
+		Buckets: prometheus.ExponentialBuckets(128, 4, 8),
+	}, []string{"chain_id", "node", "method"})
+)
+
+var tracer = otel.Tracer("github.com/smartcontractkit/chainlink-starknet/relayer/pkg/starknet")
+
+// startSpan starts the client span of a request to a node
+func startSpan(ctx context.Context, chainID, node, method string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("rpc.method", method),
+		attribute.String("starknet.chain_id", chainID),
+		attribute.String("starknet.node", node),
+	))
+}
+
+// endSpan records the error of the request, if any, and ends the span
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// observeRequest records the duration and status of a single request to a node
+func observeRequest(chainID, node, method string, start time.Time, err error) {
+	status := statusSuccess
+	if err != nil {
+		status = statusError
+		if IsRetryable(err) {
+			status = statusRetryableError
+		}
+	}
+	promRPCRequests.WithLabelValues(chainID, node, method, status).Inc()
+	promRPCRequestDuration.WithLabelValues(chainID, node, method).Observe(time.Since(start).Seconds())
+}
+
+// Instrument records the metrics and span of a request sent directly through the Provider, it isn't retried
+func (c *Client) Instrument(ctx context.Context, method string, request func(ctx context.Context) error) (err error) {
+	ctx, span := startSpan(withMethod(ctx, method), c.chainID, c.node, method)
+	defer func() { endSpan(span, err) }()
+	defer func(start time.Time) { observeRequest(c.chainID, c.node, method, start, err) }(time.Now())
+	return request(ctx)
+}
+
+type methodKey struct{}
+
+// withMethod sets the method of the HTTP requests sent with ctx, for the instrumented transport
+func withMethod(ctx context.Context, method string) context.Context {
+	return context.WithValue(ctx, methodKey{}, method)
+}
+
+func methodFromContext(ctx context.Context) string {
+	if method, ok := ctx.Value(methodKey{}).(string); ok {
+		return method
+	}
+	return methodOther
+}
+
+// instrumentedTransport records the size of the HTTP requests and responses
+type instrumentedTransport struct {
+	chainID string
+	node    string
+	next    http.RoundTripper
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	method := methodFromContext(req.Context())
+	if req.ContentLength > 0 {
+		promRPCRequestSize.WithLabelValues(t.chainID, t.node, method).Observe(float64(req.ContentLength))
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &countingBody{ReadCloser: resp.Body, observer: promRPCResponseSize.WithLabelValues(t.chainID, t.node, method)}
+	return resp, nil
+}
+
+// countingBody observes the bytes read from the body once it is read to the end or closed, go-ethereum doesn't close
+// the body of failed requests
+type countingBody struct {
+	io.ReadCloser
+	observer prometheus.Observer
+	read     int
+	observed bool
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += n
+	if err == io.EOF {
+		b.observe()
+	}
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	b.observe()
+	return b.ReadCloser.Close()
+}
+
+func (b *countingBody) observe() {
+	if !b.observed {
+		b.observed = true
+		b.observer.Observe(float64(b.read))
+	}
+}
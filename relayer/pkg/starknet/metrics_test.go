@@ -0,0 +1,76 @@
+package starknet
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func TestClient_Instrumentation(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+
+	var throttled atomic.Bool
+	throttled.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if throttled.Swap(false) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, err := w.Write([]byte(`{"jsonrpc": "2.0", "id": 1, "result": 1}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	policy := RetryPolicy{MaxRetries: 1, MinWait: time.Millisecond}
+	client, err := NewClient("SN_INSTRUMENTED", server.URL, "", logger.Test(t), &timeout, WithRetryPolicy(policy), WithNodeName("primary"))
+	require.NoError(t, err)
+
+	_, err = client.LatestBlockHeight(tests.Context(t))
+	require.NoError(t, err)
+
+	// every attempt is a request
+	assert.Equal(t, 1.0, testutil.ToFloat64(promRPCRequests.WithLabelValues("SN_INSTRUMENTED", "primary", "starknet_blockNumber", statusRetryableError)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(promRPCRequests.WithLabelValues("SN_INSTRUMENTED", "primary", "starknet_blockNumber", statusSuccess)))
+	for _, histogram := range []*prometheus.HistogramVec{promRPCRequestSize, promRPCResponseSize} {
+		var m dto.Metric
+		require.NoError(t, histogram.WithLabelValues("SN_INSTRUMENTED", "primary", "starknet_blockNumber").(prometheus.Histogram).Write(&m))
+		assert.Equal(t, uint64(2), m.GetHistogram().GetSampleCount())
+	}
+
+	// the call is a single span, with an event per retry
+	ended := spans.Ended()
+	require.Len(t, ended, 1)
+	span := ended[0]
+	assert.Equal(t, "starknet_blockNumber", span.Name())
+	assert.Equal(t, codes.Unset, span.Status().Code)
+	assert.Contains(t, span.Attributes(), attribute.String("starknet.chain_id", "SN_INSTRUMENTED"))
+	assert.Contains(t, span.Attributes(), attribute.String("starknet.node", "primary"))
+	require.Len(t, span.Events(), 1)
+	assert.Equal(t, "retry", span.Events()[0].Name)
+
+	t.Run("feeder", func(t *testing.T) {
+		feeder := NewTestFeederClient(t).WithChainID("SN_INSTRUMENTED")
+		_, err := feeder.TransactionFailure(tests.Context(t), new(felt.Felt).SetUint64(1))
+		require.NoError(t, err)
+		assert.Equal(t, 1.0, testutil.ToFloat64(promRPCRequests.WithLabelValues("SN_INSTRUMENTED", feederNode, "get_transaction", statusSuccess)))
+		assert.Len(t, spans.Ended(), 2)
+	})
+}
@@ -196,6 +196,36 @@ func (_m *Reader) LatestBlockHeight(_a0 context.Context) (uint64, error) {
 	return r0, r1
 }
 
+// StorageAt provides a mock function with given fields: ctx, contractAddress, variable
+func (_m *Reader) StorageAt(ctx context.Context, contractAddress *felt.Felt, variable string) (*felt.Felt, error) {
+	ret := _m.Called(ctx, contractAddress, variable)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StorageAt")
+	}
+
+	var r0 *felt.Felt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *felt.Felt, string) (*felt.Felt, error)); ok {
+		return rf(ctx, contractAddress, variable)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *felt.Felt, string) *felt.Felt); ok {
+		r0 = rf(ctx, contractAddress, variable)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*felt.Felt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *felt.Felt, string) error); ok {
+		r1 = rf(ctx, contractAddress, variable)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // TransactionByHash provides a mock function with given fields: _a0, _a1
 func (_m *Reader) TransactionByHash(_a0 context.Context, _a1 *felt.Felt) (rpc.Transaction, error) {
 	ret := _m.Called(_a0, _a1)
@@ -0,0 +1,82 @@
+package starknet
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket refilled at a fixed rate, a request takes one token and waits if there is none
+type RateLimiter struct {
+	lock   sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter allows requestsPerSecond on average, with bursts of up to burst requests. A rate of 0 or less
+// doesn't limit requests.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Rate returns the requests per second of the limiter
+func (l *RateLimiter) Rate() float64 {
+	return l.rate
+}
+
+// Wait takes a token, blocking until one is available or the context is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay := l.reserve()
+		if delay == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// reserve takes a token if one is available, otherwise it returns how long until the next token
+func (l *RateLimiter) reserve() time.Duration {
+	if l.rate <= 0 {
+		return 0
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return max(time.Duration((1-l.tokens)/l.rate*float64(time.Second)), time.Nanosecond)
+}
+
+// rateLimitedTransport waits for the limiter before each HTTP request
+type rateLimitedTransport struct {
+	limiter *RateLimiter
+	next    http.RoundTripper
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
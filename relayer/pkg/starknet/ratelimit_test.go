@@ -0,0 +1,73 @@
+package starknet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func TestRateLimiter(t *testing.T) {
+	ctx := tests.Context(t)
+
+	t.Run("burst then rate", func(t *testing.T) {
+		l := NewRateLimiter(20, 2)
+		start := time.Now()
+		for i := 0; i < 4; i++ {
+			require.NoError(t, l.Wait(ctx))
+		}
+		// 2 tokens of burst, then 2 more at 20/s
+		assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		l := NewRateLimiter(0, 0)
+		for i := 0; i < 100; i++ {
+			require.NoError(t, l.Wait(ctx))
+		}
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		l := NewRateLimiter(0.001, 1)
+		require.NoError(t, l.Wait(ctx))
+		waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, l.Wait(waitCtx), context.DeadlineExceeded)
+	})
+}
+
+func TestClientOptions(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "key", r.Header.Get("x-apikey"))
+		_, err := w.Write([]byte(`{"jsonrpc": "2.0", "id": 1, "result": 1}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	limiter := NewRateLimiter(1, 1)
+	client, err := NewClient(chainID, server.URL, "key", logger.Test(t), &timeout,
+		WithHeaders(map[string]string{"Authorization": "Bearer token"}), WithRateLimiter(limiter))
+	require.NoError(t, err)
+
+	ctx := tests.Context(t)
+	_, err = client.LatestBlockHeight(ctx)
+	require.NoError(t, err)
+
+	// the single token was taken by the first request
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = client.LatestBlockHeight(waitCtx)
+	require.Error(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+}
@@ -0,0 +1,123 @@
+package starknet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RetryPolicy retries the reads of a Client that failed for reasons unrelated to the request, like a rate limited
+// or unavailable node. Each attempt gets the full request timeout of the client.
+type RetryPolicy struct {
+	// MaxRetries after the first attempt, 0 disables retries
+	MaxRetries int
+	// MinWait is the wait before the first retry, which grows with Backoff up to MaxWait
+	MinWait time.Duration
+	MaxWait time.Duration
+	Backoff Backoff
+}
+
+// WithRetryPolicy retries the Reader and ChainClient methods of the client, by default they aren't retried
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(o *clientOptions) {
+		o.retryPolicy = policy
+	}
+}
+
+// httpStatusError matches the HTTP errors go-ethereum returns, which starknet.go flattens into the data of an
+// internal error
+var httpStatusError = regexp.MustCompile(`^(\d{3}) `)
+
+// retryableErrors are network errors that starknet.go flattens into the data of an internal error
+var retryableErrors = []string{
+	"connection refused",
+	"connection reset",
+	"i/o timeout",
+	"EOF",
+	"context deadline exceeded",
+}
+
+// IsRetryable reports whether a failed request can succeed when sent again: the node rate limited the request
+// (HTTP 429), was unavailable (HTTP 5xx), or the connection failed or timed out. Errors the node returns for the
+// request itself, like a reverted call, are permanent.
+func IsRetryable(err error) bool {
+	var httpErr ethrpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return retryableStatus(httpErr.StatusCode)
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var rpcErr *starknetrpc.RPCError
+	if errors.As(err, &rpcErr) && rpcErr.Code == starknetrpc.InternalError {
+		data := fmt.Sprint(rpcErr.Data)
+		if m := httpStatusError.FindStringSubmatch(data); m != nil {
+			var status int
+			_, _ = fmt.Sscan(m[1], &status)
+			return retryableStatus(status)
+		}
+		for _, s := range retryableErrors {
+			if strings.Contains(data, s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func retryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// do runs a request with the request timeout of the client, retrying it according to the retry policy. The request
+// is traced and every attempt is recorded in the metrics of method.
+func (c *Client) do(ctx context.Context, method string, request func(ctx context.Context) error) (err error) {
+	ctx, span := startSpan(withMethod(ctx, method), c.chainID, c.node, method)
+	defer func() { endSpan(span, err) }()
+
+	wait := c.retryPolicy.MinWait
+	for attempt := 0; ; attempt++ {
+		err = c.attempt(ctx, method, request)
+		if err == nil || attempt >= c.retryPolicy.MaxRetries || ctx.Err() != nil || !IsRetryable(err) {
+			return err
+		}
+		c.lggr.Debugw("retrying failed request", "method", method, "attempt", attempt+1, "wait", wait, "err", err)
+		span.AddEvent("retry", trace.WithAttributes(attribute.Int("attempt", attempt+1), attribute.String("error", err.Error())))
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		if c.retryPolicy.Backoff != nil {
+			wait = c.retryPolicy.Backoff(wait)
+		}
+		if c.retryPolicy.MaxWait > 0 {
+			wait = min(wait, c.retryPolicy.MaxWait)
+		}
+	}
+}
+
+func (c *Client) attempt(ctx context.Context, method string, request func(ctx context.Context) error) (err error) {
+	if c.defaultTimeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.defaultTimeout)
+		defer cancel()
+	}
+	defer func(start time.Time) { observeRequest(c.chainID, c.node, method, start, err) }(time.Now())
+	return request(ctx)
+}
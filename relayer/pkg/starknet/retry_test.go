@@ -0,0 +1,110 @@
+package starknet
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	starknetrpc "github.com/NethermindEth/starknet.go/rpc"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func TestIsRetryable(t *testing.T) {
+	internal := func(data string) error {
+		return &starknetrpc.RPCError{Code: starknetrpc.InternalError, Message: "Internal Error", Data: data}
+	}
+	for _, tc := range []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"too many requests", ethrpc.HTTPError{StatusCode: 429, Status: "429 Too Many Requests"}, true},
+		{"bad gateway", ethrpc.HTTPError{StatusCode: 502, Status: "502 Bad Gateway"}, true},
+		{"unauthorized", ethrpc.HTTPError{StatusCode: 401, Status: "401 Unauthorized"}, false},
+		{"flattened too many requests", internal("429 Too Many Requests: slow down"), true},
+		{"flattened service unavailable", internal("503 Service Unavailable"), true},
+		{"flattened forbidden", internal("403 Forbidden"), false},
+		{"flattened connection refused", internal("Post \"http://localhost\": dial tcp: connect: connection refused"), true},
+		{"flattened unknown", internal("unexpected response"), false},
+		{"contract error", starknetrpc.ErrContractError, false},
+		{"block not found", starknetrpc.ErrBlockNotFound, false},
+		{"other", errors.New("invalid"), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.retryable, IsRetryable(tc.err))
+		})
+	}
+}
+
+func TestClient_RetryPolicy(t *testing.T) {
+	var requests atomic.Int32
+	var status atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the first request of each test is throttled
+		if requests.Add(1) == 1 {
+			w.WriteHeader(int(status.Load()))
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		response := `{"jsonrpc": "2.0", "id": 1, "result": 1}`
+		if bytes.HasPrefix(body, []byte("[")) {
+			response = "[" + response + "]"
+		}
+		_, err = w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	policy := RetryPolicy{MaxRetries: 2, MinWait: time.Millisecond, MaxWait: 10 * time.Millisecond, Backoff: ExponentialBackoff}
+	client, err := NewClient(chainID, server.URL, "", logger.Test(t), &timeout, WithRetryPolicy(policy))
+	require.NoError(t, err)
+	ctx := tests.Context(t)
+
+	t.Run("retries throttled request", func(t *testing.T) {
+		requests.Store(0)
+		status.Store(http.StatusTooManyRequests)
+		height, err := client.LatestBlockHeight(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), height)
+		assert.Equal(t, int32(2), requests.Load())
+
+		requests.Store(0)
+		_, err = client.Batch(ctx, NewBatchBuilder().RequestChainID())
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("doesn't retry permanent error", func(t *testing.T) {
+		requests.Store(0)
+		status.Store(http.StatusUnauthorized)
+		_, err := client.LatestBlockHeight(ctx)
+		require.Error(t, err)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer failing.Close()
+		client, err := NewClient(chainID, failing.URL, "", logger.Test(t), &timeout, WithRetryPolicy(policy))
+		require.NoError(t, err)
+
+		requests.Store(0)
+		_, err = client.LatestBlockHeight(ctx)
+		require.Error(t, err)
+		assert.Equal(t, int32(3), requests.Load())
+	})
+}